@@ -0,0 +1,299 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Equal reports whether two ACEs grant or deny the same thing: same type,
+// flags, rights, object types and trustee
+func (a *ACE) Equal(other *ACE) bool {
+	if a.raw != nil || other.raw != nil {
+		return false
+	}
+
+	return a.Type == other.Type &&
+		a.Flags == other.Flags &&
+		a.Mask == other.Mask &&
+		strings.EqualFold(a.ObjectType, other.ObjectType) &&
+		strings.EqualFold(a.InheritedObjectType, other.InheritedObjectType) &&
+		strings.EqualFold(a.SID, other.SID)
+}
+
+// HasACE reports whether the DACL of an object contains the given explicit
+// ACE
+func (c *Client) HasACE(dn string, ace *ACE) (bool, error) {
+	sd, err := c.GetSecurityDescriptor(dn)
+	if err != nil {
+		return false, err
+	}
+
+	return sd.DACL != nil && indexOfACE(sd.DACL, ace) >= 0, nil
+}
+
+// AddACE adds an explicit ACE to the DACL of an object, in canonical order:
+// deny ACEs go in front, allow ACEs after the other explicit ACEs. All other
+// ACEs are left as they are. Adding an ACE that is already present is an
+// error, so that each ACE has a single owner.
+func (c *Client) AddACE(dn string, ace *ACE) error {
+	c.securityMu.Lock()
+	defer c.securityMu.Unlock()
+
+	sd, err := c.GetSecurityDescriptor(dn)
+	if err != nil {
+		return err
+	}
+	if sd.DACL == nil {
+		sd.DACL = &ACL{Revision: 4}
+	}
+
+	if indexOfACE(sd.DACL, ace) >= 0 {
+		return fmt.Errorf("the ACE %s already exists on %s", ace.SDDL(), dn)
+	}
+
+	position := 0
+	if !ace.IsDeny() {
+		position = len(sd.DACL.ACEs)
+		for i, existing := range sd.DACL.ACEs {
+			if existing.Inherited() {
+				position = i
+				break
+			}
+		}
+	}
+
+	aces := make([]*ACE, 0, len(sd.DACL.ACEs)+1)
+	aces = append(aces, sd.DACL.ACEs[:position]...)
+	aces = append(aces, ace)
+	aces = append(aces, sd.DACL.ACEs[position:]...)
+	sd.DACL.ACEs = aces
+
+	return c.SetSecurityDescriptor(dn, sd)
+}
+
+// RemoveACE removes an explicit ACE from the DACL of an object, leaving all
+// other ACEs as they are. Removing an ACE that is not present is a no-op.
+func (c *Client) RemoveACE(dn string, ace *ACE) error {
+	c.securityMu.Lock()
+	defer c.securityMu.Unlock()
+
+	sd, err := c.GetSecurityDescriptor(dn)
+	if err != nil {
+		return err
+	}
+	if sd.DACL == nil {
+		return nil
+	}
+
+	i := indexOfACE(sd.DACL, ace)
+	if i < 0 {
+		return nil
+	}
+
+	sd.DACL.ACEs = append(sd.DACL.ACEs[:i], sd.DACL.ACEs[i+1:]...)
+
+	return c.SetSecurityDescriptor(dn, sd)
+}
+
+// indexOfACE returns the index of the first explicit ACE equal to ace, or
+// -1 if there is none
+func indexOfACE(acl *ACL, ace *ACE) int {
+	for i, existing := range acl.ACEs {
+		if !existing.Inherited() && existing.Equal(ace) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ResolveObjectType returns the GUID to use as an ACE object type for a
+// name: the schemaIDGUID of an attribute or class (e.g. "member",
+// "group"), or the rightsGuid of an extended right, validated write or
+// property set (e.g. "Reset-Password"). GUIDs are returned as they are.
+func (c *Client) ResolveObjectType(name string) (string, error) {
+	if _, err := ParseGUID(name); err == nil {
+		return strings.ToLower(strings.Trim(name, "{}")), nil
+	}
+
+	c.schemaMu.Lock()
+	schemaDN, err := c.schemaNamingContext()
+	c.schemaMu.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		schemaDN,
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&(|(objectClass=attributeSchema)(objectClass=classSchema))(lDAPDisplayName=%s))", EscapeFilter(name)),
+		[]string{"schemaIDGUID"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to search schema for %s: %w", name, err)
+	}
+
+	if len(result.Entries) > 0 {
+		return FormatGUID(result.Entries[0].GetRawAttributeValue("schemaIDGUID")), nil
+	}
+
+	// Extended rights live in the configuration partition, which holds the
+	// schema partition
+	configurationDN, err := ParentDN(schemaDN)
+	if err != nil {
+		return "", err
+	}
+
+	searchRequest = ldap.NewSearchRequest(
+		"CN=Extended-Rights,"+configurationDN,
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&(objectClass=controlAccessRight)(|(cn=%[1]s)(displayName=%[1]s)))", EscapeFilter(name)),
+		[]string{"rightsGuid"},
+		nil,
+	)
+
+	result, err = c.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to search extended rights for %s: %w", name, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return "", fmt.Errorf("no attribute, class or extended right named %s", name)
+	}
+
+	return strings.ToLower(result.Entries[0].GetAttributeValue("rightsGuid")), nil
+}
+
+// ObjectTypeName returns the name of the attribute, class or extended right
+// with the given GUID: the lDAPDisplayName from the schema, or the cn of the
+// extended right. It is the inverse of ResolveObjectType.
+func (c *Client) ObjectTypeName(guid string) (string, error) {
+	raw, err := ParseGUID(guid)
+	if err != nil {
+		return "", err
+	}
+
+	c.schemaMu.Lock()
+	schemaDN, err := c.schemaNamingContext()
+	c.schemaMu.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		schemaDN,
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&(|(objectClass=attributeSchema)(objectClass=classSchema))(schemaIDGUID=%s))", escapeFilterBytes(raw)),
+		[]string{"lDAPDisplayName"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to search schema for %s: %w", guid, err)
+	}
+
+	if len(result.Entries) > 0 {
+		return result.Entries[0].GetAttributeValue("lDAPDisplayName"), nil
+	}
+
+	configurationDN, err := ParentDN(schemaDN)
+	if err != nil {
+		return "", err
+	}
+
+	searchRequest = ldap.NewSearchRequest(
+		"CN=Extended-Rights,"+configurationDN,
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&(objectClass=controlAccessRight)(rightsGuid=%s))", EscapeFilter(strings.ToLower(strings.Trim(guid, "{}")))),
+		[]string{"cn"},
+		nil,
+	)
+
+	result, err = c.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to search extended rights for %s: %w", guid, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return "", fmt.Errorf("no attribute, class or extended right with GUID %s", guid)
+	}
+
+	return result.Entries[0].GetAttributeValue("cn"), nil
+}
+
+// AttributeMemberGUID is the schemaIDGUID of the member attribute
+const AttributeMemberGUID = "bf9679c0-0de6-11d0-a285-00aa003049e2"
+
+// ManagerMembershipACE returns the ACE behind ADUC's "Manager can update
+// membership list" checkbox: write access to the member attribute for the
+// manager's SID
+func ManagerMembershipACE(sid string) *ACE {
+	return &ACE{
+		Type:       ACETypeAccessAllowedObject,
+		Mask:       RightDSWriteProperty,
+		ObjectType: AttributeMemberGUID,
+		SID:        sid,
+	}
+}
+
+// ManagerCanUpdateMembership reports whether the manager of a group holds
+// the ACE of ManagerMembershipACE on it. Managers that no longer exist or
+// have no SID (e.g. contacts) can't hold it.
+func (c *Client) ManagerCanUpdateMembership(groupDN, managerDN string) (bool, error) {
+	sid, err := c.GetObjectSID(managerDN)
+	if err != nil {
+		if IsNotFound(err) || errors.Is(err, ErrNotSecurityPrincipal) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return c.HasACE(groupDN, ManagerMembershipACE(sid))
+}
+
+// SetManagerCanUpdateMembership grants or revokes the manager's write access
+// to the member attribute of a group. Revoking it for a manager that no
+// longer exists is a no-op, as its SID can't be resolved anymore.
+func (c *Client) SetManagerCanUpdateMembership(groupDN, managerDN string, allowed bool) error {
+	sid, err := c.GetObjectSID(managerDN)
+	if err != nil {
+		if !allowed && (IsNotFound(err) || errors.Is(err, ErrNotSecurityPrincipal)) {
+			return nil
+		}
+		return fmt.Errorf("failed to resolve manager %s: %w", managerDN, err)
+	}
+
+	ace := ManagerMembershipACE(sid)
+	if !allowed {
+		return c.RemoveACE(groupDN, ace)
+	}
+
+	found, err := c.HasACE(groupDN, ace)
+	if err != nil || found {
+		return err
+	}
+
+	return c.AddACE(groupDN, ace)
+}
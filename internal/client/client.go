@@ -3,11 +3,17 @@ package client
 import (
 	"crypto/tls"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/go-ldap/ldap/v3"
 )
 
+// searchPageSize is the page size used for paged searches. Active Directory
+// limits pages to 1000 entries by default.
+const searchPageSize = 1000
+
 // Client represents an LDAP client for Active Directory operations
 type Client struct {
 	conn     *ldap.Conn
@@ -17,6 +23,32 @@ type Client struct {
 	server   string
 	port     int
 	useTLS   bool
+	insecure bool
+
+	// globalCatalog is the host searched for forest-wide lookups
+	globalCatalog string
+
+	// gidNumber allocation range for POSIX groups
+	gidNumberMin     int64
+	gidNumberMax     int64
+	gidNumberCounter string
+
+	// guard against writes to privileged groups
+	privilegedWritesEnabled bool
+	privilegedGroups        []string
+
+	// how destroyed groups are removed
+	deletionMode string
+	quarantineOU string
+
+	// schema lookups are cached since the schema rarely changes
+	schemaMu    sync.Mutex
+	schemaDN    string
+	schemaCache map[string]*AttributeSchema
+
+	// securityMu serializes read-modify-write cycles on security
+	// descriptors, so concurrent ACE changes to one object aren't lost
+	securityMu sync.Mutex
 }
 
 // ClientConfig holds the configuration for the LDAP client
@@ -28,6 +60,28 @@ type ClientConfig struct {
 	Password string
 	UseTLS   bool
 	Insecure bool
+
+	// GlobalCatalogServer is the global catalog host for forest-wide
+	// searches; Server is used if empty
+	GlobalCatalogServer string
+
+	// GIDNumberMin and GIDNumberMax bound automatic gidNumber allocation;
+	// GIDNumberCounterDN is the object holding the optional
+	// msSFU30MaxGidNumber counter, found under the NIS domains if empty
+	GIDNumberMin       int64
+	GIDNumberMax       int64
+	GIDNumberCounterDN string
+
+	// PrivilegedWritesEnabled allows resources that opt in to write to
+	// privileged groups; PrivilegedGroups lists additional groups (DNs or
+	// sAMAccountNames) to treat as privileged
+	PrivilegedWritesEnabled bool
+	PrivilegedGroups        []string
+
+	// DeletionMode is DeletionModeDelete or DeletionModeQuarantine; in
+	// quarantine mode destroyed groups are moved to QuarantineOU
+	DeletionMode string
+	QuarantineOU string
 }
 
 // NewClient creates a new LDAP client
@@ -39,6 +93,19 @@ func NewClient(config *ClientConfig) (*Client, error) {
 		server:   config.Server,
 		port:     config.Port,
 		useTLS:   config.UseTLS,
+		insecure: config.Insecure,
+
+		globalCatalog: config.GlobalCatalogServer,
+
+		gidNumberMin:     config.GIDNumberMin,
+		gidNumberMax:     config.GIDNumberMax,
+		gidNumberCounter: config.GIDNumberCounterDN,
+
+		privilegedWritesEnabled: config.PrivilegedWritesEnabled,
+		privilegedGroups:        config.PrivilegedGroups,
+
+		deletionMode: config.DeletionMode,
+		quarantineOU: config.QuarantineOU,
 	}
 
 	err := client.connect(config.Insecure)
@@ -99,6 +166,21 @@ func (c *Client) Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult,
 	return result, nil
 }
 
+// SearchPaged performs an LDAP search using the paging control, so that
+// result sets larger than the server's MaxPageSize are returned in full
+func (c *Client) SearchPaged(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	if c.conn == nil {
+		return nil, fmt.Errorf("LDAP connection is not established")
+	}
+
+	result, err := c.conn.SearchWithPaging(searchRequest, searchPageSize)
+	if err != nil {
+		return nil, fmt.Errorf("LDAP paged search failed: %w", err)
+	}
+
+	return result, nil
+}
+
 // Add adds an entry to LDAP
 func (c *Client) Add(addRequest *ldap.AddRequest) error {
 	if c.conn == nil {
@@ -141,6 +223,111 @@ func (c *Client) Delete(delRequest *ldap.DelRequest) error {
 	return nil
 }
 
+// ModifyDN renames or moves an entry in LDAP
+func (c *Client) ModifyDN(modifyDNRequest *ldap.ModifyDNRequest) error {
+	if c.conn == nil {
+		return fmt.Errorf("LDAP connection is not established")
+	}
+
+	err := c.conn.ModifyDN(modifyDNRequest)
+	if err != nil {
+		return fmt.Errorf("LDAP modify DN failed: %w", err)
+	}
+
+	return nil
+}
+
+// RenameObject renames and/or moves an entry using a modify DN operation and
+// returns its new DN. The objectGUID is preserved.
+func (c *Client) RenameObject(dn, rdn, parentDN string) (string, error) {
+	_, currentParent, err := SplitDN(dn)
+	if err != nil {
+		return "", err
+	}
+
+	// Only pass a new superior when the entry actually moves
+	newSuperior := ""
+	if !DNEqual(currentParent, parentDN) {
+		newSuperior = parentDN
+	}
+
+	modifyDNRequest := ldap.NewModifyDNRequest(dn, rdn, true, newSuperior)
+	if err := c.ModifyDN(modifyDNRequest); err != nil {
+		return "", fmt.Errorf("failed to rename %s to %s,%s: %w", dn, rdn, parentDN, err)
+	}
+
+	return fmt.Sprintf("%s,%s", rdn, parentDN), nil
+}
+
+// entryAttributes returns the attributes of a new object: the given
+// attributes, with the ones its type requires taking precedence
+func entryAttributes(attributes, required map[string][]string) map[string][]string {
+	merged := make(map[string][]string, len(attributes)+len(required))
+	for name, values := range attributes {
+		merged[name] = values
+	}
+	for name, values := range required {
+		merged[name] = values
+	}
+	return merged
+}
+
+// addEntry creates an object in a single add operation. Attributes are added
+// in a stable order; those without values are left out.
+func (c *Client) addEntry(dn string, objectClasses []string, attributes map[string][]string) error {
+	addRequest := ldap.NewAddRequest(dn, nil)
+	addRequest.Attribute("objectClass", objectClasses)
+
+	for _, name := range sortedAttributeNames(attributes) {
+		if values := attributes[name]; len(values) > 0 {
+			addRequest.Attribute(name, values)
+		}
+	}
+
+	return c.Add(addRequest)
+}
+
+// modifyAttributes replaces attributes of an object in a single modify
+// operation. Attributes without values are cleared.
+func (c *Client) modifyAttributes(dn string, changes map[string][]string) error {
+	modifyRequest := ldap.NewModifyRequest(dn, nil)
+
+	for _, name := range sortedAttributeNames(changes) {
+		if values := changes[name]; len(values) == 0 {
+			modifyRequest.Delete(name, []string{})
+		} else {
+			modifyRequest.Replace(name, values)
+		}
+	}
+
+	return c.Modify(modifyRequest)
+}
+
+// sortedAttributeNames returns the attribute names in a stable order
+func sortedAttributeNames(attributes map[string][]string) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseScope converts a search scope name ("base", "one" or "subtree") to
+// the corresponding LDAP scope
+func ParseScope(scope string) (int, error) {
+	switch strings.ToLower(scope) {
+	case "base":
+		return ldap.ScopeBaseObject, nil
+	case "one", "onelevel":
+		return ldap.ScopeSingleLevel, nil
+	case "", "sub", "subtree":
+		return ldap.ScopeWholeSubtree, nil
+	default:
+		return 0, fmt.Errorf("invalid search scope %q, expected one of: base, one, subtree", scope)
+	}
+}
+
 // EscapeDN escapes special characters in a DN component
 func EscapeDN(value string) string {
 	// Escape special characters in DN values
@@ -160,6 +347,61 @@ func EscapeDN(value string) string {
 	return replacer.Replace(value)
 }
 
+// ParentDN returns the DN of the parent of an entry
+func ParentDN(dn string) (string, error) {
+	_, parent, err := SplitDN(dn)
+	if err != nil {
+		return "", err
+	}
+	if parent == "" {
+		return "", fmt.Errorf("DN %q has no parent", dn)
+	}
+
+	return parent, nil
+}
+
+// SplitDN splits a DN into its first RDN and the DN of its parent, keeping
+// the original spelling of both
+func SplitDN(dn string) (rdn, parent string, err error) {
+	if _, err := ldap.ParseDN(dn); err != nil {
+		return "", "", fmt.Errorf("invalid DN %q: %w", dn, err)
+	}
+
+	for i := 0; i < len(dn); i++ {
+		switch dn[i] {
+		case '\\':
+			// Skip the escaped character
+			i++
+		case ',':
+			return strings.TrimSpace(dn[:i]), strings.TrimSpace(dn[i+1:]), nil
+		}
+	}
+
+	return strings.TrimSpace(dn), "", nil
+}
+
+// DNEqual compares two DNs case-insensitively, falling back to a plain
+// comparison when either fails to parse
+func DNEqual(a, b string) bool {
+	parsedA, errA := ldap.ParseDN(a)
+	parsedB, errB := ldap.ParseDN(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	return parsedA.EqualFold(parsedB)
+}
+
+// escapeFilterBytes escapes every byte of a binary value, e.g. an
+// objectGUID, for use in a search filter
+func escapeFilterBytes(raw []byte) string {
+	var escaped strings.Builder
+	for _, b := range raw {
+		fmt.Fprintf(&escaped, "\\%02x", b)
+	}
+	return escaped.String()
+}
+
 // EscapeFilter escapes special characters in a search filter
 func EscapeFilter(value string) string {
 	// Escape special characters in filter values
@@ -172,3 +414,16 @@ func EscapeFilter(value string) string {
 	)
 	return replacer.Replace(value)
 }
+
+// ParseBoolean parses an LDAP Boolean syntax value ("TRUE" or "FALSE")
+func ParseBoolean(value string) bool {
+	return strings.EqualFold(value, "TRUE")
+}
+
+// FormatBoolean formats a value in LDAP Boolean syntax
+func FormatBoolean(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// computerFilter matches computer accounts
+const computerFilter = "(objectCategory=computer)"
+
+// Computer represents an Active Directory computer account
+type Computer struct {
+	DN              string   `json:"dn"`
+	CN              string   `json:"cn"`
+	SamAccountName  string   `json:"sam_account_name"`
+	DNSHostName     string   `json:"dns_host_name"`
+	Description     string   `json:"description"`
+	ManagedBy       string   `json:"managed_by"`
+	Enabled         bool     `json:"enabled"`
+	OperatingSystem string   `json:"operating_system"`
+	WhenCreated     string   `json:"when_created"`
+	MemberOf        []string `json:"member_of"`
+	ObjectGUID      string   `json:"object_guid"`
+	ObjectSid       string   `json:"object_sid"`
+}
+
+// computerAttributes lists the attributes fetched for every computer lookup
+var computerAttributes = []string{
+	"cn",
+	"sAMAccountName",
+	"dNSHostName",
+	"description",
+	"managedBy",
+	"userAccountControl",
+	"operatingSystem",
+	"whenCreated",
+	"memberOf",
+	"objectGUID",
+	"objectSid",
+}
+
+// computerFromEntry maps a search result entry to a Computer
+func computerFromEntry(entry *ldap.Entry) *Computer {
+	uac, _ := strconv.ParseInt(entry.GetAttributeValue("userAccountControl"), 10, 64)
+
+	return &Computer{
+		DN:              entry.DN,
+		CN:              entry.GetAttributeValue("cn"),
+		SamAccountName:  entry.GetAttributeValue("sAMAccountName"),
+		DNSHostName:     entry.GetAttributeValue("dNSHostName"),
+		Description:     entry.GetAttributeValue("description"),
+		ManagedBy:       entry.GetAttributeValue("managedBy"),
+		Enabled:         uac&UACAccountDisable == 0,
+		OperatingSystem: entry.GetAttributeValue("operatingSystem"),
+		WhenCreated:     FormatGeneralizedTime(entry.GetAttributeValue("whenCreated")),
+		MemberOf:        entry.GetAttributeValues("memberOf"),
+		ObjectGUID:      FormatGUID(entry.GetRawAttributeValue("objectGUID")),
+		ObjectSid:       FormatSID(entry.GetRawAttributeValue("objectSid")),
+	}
+}
+
+// ComputerSAMAccountName returns the sAMAccountName of a computer account,
+// which always ends in "$". Names are accepted with or without it.
+func ComputerSAMAccountName(name string) string {
+	if strings.HasSuffix(name, "$") {
+		return name
+	}
+	return name + "$"
+}
+
+// GetComputer retrieves a computer by its distinguished name
+func (c *Client) GetComputer(dn string) (*Computer, error) {
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		computerFilter,
+		computerAttributes,
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for computer %s: %w", dn, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("computer not found: %s", dn)
+	}
+
+	return computerFromEntry(result.Entries[0]), nil
+}
+
+// GetComputerByGUID retrieves a computer by its objectGUID
+func (c *Client) GetComputerByGUID(guid string) (*Computer, error) {
+	computer, err := c.GetComputer(GUIDReference(guid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find computer with GUID %s: %w", guid, err)
+	}
+
+	return computer, nil
+}
+
+// GetComputerBySAM retrieves a computer by its SAM account name, with or
+// without the trailing "$"
+func (c *Client) GetComputerBySAM(samAccountName string) (*Computer, error) {
+	return c.findComputer("sAMAccountName", ComputerSAMAccountName(samAccountName))
+}
+
+// GetComputerByDNSHostName retrieves a computer by its DNS host name
+func (c *Client) GetComputerByDNSHostName(dnsHostName string) (*Computer, error) {
+	return c.findComputer("dNSHostName", dnsHostName)
+}
+
+// findComputer searches the directory for exactly one computer whose
+// attribute matches the given value
+func (c *Client) findComputer(attribute, value string) (*Computer, error) {
+	filter := fmt.Sprintf("(&%s(%s=%s))", computerFilter, attribute, EscapeFilter(value))
+
+	searchRequest := ldap.NewSearchRequest(
+		c.baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter,
+		computerAttributes,
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for computer with %s %s: %w", attribute, value, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("computer not found with %s: %s", attribute, value)
+	}
+
+	if len(result.Entries) > 1 {
+		return nil, &AmbiguousResultError{
+			ObjectType: "computer",
+			Attribute:  attribute,
+			Value:      value,
+			DNs:        entryDNs(result.Entries),
+		}
+	}
+
+	return computerFromEntry(result.Entries[0]), nil
+}
+
+// CreateComputer pre-stages a computer account in a single add operation
+// and returns its DN. The account is created the way Active Directory Users
+// and Computers does it: a workstation trust account without a password,
+// which the machine sets when it joins.
+func (c *Client) CreateComputer(ou, cn, samAccountName string, enabled bool, attributes map[string][]string) (string, error) {
+	dn := fmt.Sprintf("CN=%s,%s", EscapeDN(cn), ou)
+
+	userAccountControl := UACWorkstationTrustAccount | UACPasswordNotRequired
+	if !enabled {
+		userAccountControl |= UACAccountDisable
+	}
+
+	required := map[string][]string{
+		"cn":                 {cn},
+		"sAMAccountName":     {ComputerSAMAccountName(samAccountName)},
+		"userAccountControl": {strconv.Itoa(userAccountControl)},
+	}
+
+	err := c.addEntry(dn, []string{"top", "person", "organizationalPerson", "user", "computer"}, entryAttributes(attributes, required))
+	if err != nil {
+		return "", fmt.Errorf("failed to create computer %s: %w", dn, err)
+	}
+
+	return dn, nil
+}
+
+// UpdateComputer updates an existing computer
+func (c *Client) UpdateComputer(dn string, updates map[string][]string) error {
+	err := c.modifyAttributes(dn, updates)
+	if err != nil {
+		return fmt.Errorf("failed to update computer %s: %w", dn, err)
+	}
+
+	return nil
+}
+
+// DeleteComputer deletes a computer account. Joined computers usually have
+// child objects (service connection points, BitLocker recovery
+// information), so the tree delete control is used like Active Directory
+// Users and Computers does.
+func (c *Client) DeleteComputer(dn string) error {
+	delRequest := ldap.NewDelRequest(dn, []ldap.Control{ldap.NewControlSubtreeDelete()})
+
+	err := c.Delete(delRequest)
+	if err != nil {
+		return fmt.Errorf("failed to delete computer %s: %w", dn, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// contactFilter matches contact objects
+const contactFilter = "(&(objectClass=contact)(objectCategory=person))"
+
+// Contact represents an Active Directory contact
+type Contact struct {
+	DN            string `json:"dn"`
+	CN            string `json:"cn"`
+	DisplayName   string `json:"display_name"`
+	Email         string `json:"email"`
+	TargetAddress string `json:"target_address"`
+	Description   string `json:"description"`
+	ObjectGUID    string `json:"object_guid"`
+}
+
+// contactAttributes lists the attributes fetched for every contact lookup
+var contactAttributes = []string{
+	"cn",
+	"displayName",
+	"mail",
+	"targetAddress",
+	"description",
+	"objectGUID",
+}
+
+// contactFromEntry maps a search result entry to a Contact
+func contactFromEntry(entry *ldap.Entry) *Contact {
+	return &Contact{
+		DN:            entry.DN,
+		CN:            entry.GetAttributeValue("cn"),
+		DisplayName:   entry.GetAttributeValue("displayName"),
+		Email:         entry.GetAttributeValue("mail"),
+		TargetAddress: entry.GetAttributeValue("targetAddress"),
+		Description:   entry.GetAttributeValue("description"),
+		ObjectGUID:    FormatGUID(entry.GetRawAttributeValue("objectGUID")),
+	}
+}
+
+// GetContact retrieves a contact by its distinguished name
+func (c *Client) GetContact(dn string) (*Contact, error) {
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		contactFilter,
+		contactAttributes,
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for contact %s: %w", dn, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("contact not found: %s", dn)
+	}
+
+	return contactFromEntry(result.Entries[0]), nil
+}
+
+// GetContactByGUID retrieves a contact by its objectGUID
+func (c *Client) GetContactByGUID(guid string) (*Contact, error) {
+	contact, err := c.GetContact(GUIDReference(guid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find contact with GUID %s: %w", guid, err)
+	}
+
+	return contact, nil
+}
+
+// GetContactByEmail retrieves a contact by its mail attribute
+func (c *Client) GetContactByEmail(email string) (*Contact, error) {
+	searchRequest := ldap.NewSearchRequest(
+		c.baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&%s(mail=%s))", contactFilter, EscapeFilter(email)),
+		contactAttributes,
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for contact with mail %s: %w", email, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("contact not found with mail: %s", email)
+	}
+
+	if len(result.Entries) > 1 {
+		return nil, &AmbiguousResultError{
+			ObjectType: "contact",
+			Attribute:  "mail",
+			Value:      email,
+			DNs:        entryDNs(result.Entries),
+		}
+	}
+
+	return contactFromEntry(result.Entries[0]), nil
+}
+
+// CreateContact creates a new contact in a single add operation and returns
+// its DN
+func (c *Client) CreateContact(ou, cn string, attributes map[string][]string) (string, error) {
+	dn := fmt.Sprintf("CN=%s,%s", EscapeDN(cn), ou)
+
+	required := map[string][]string{
+		"cn": {cn},
+	}
+
+	err := c.addEntry(dn, []string{"top", "person", "organizationalPerson", "contact"}, entryAttributes(attributes, required))
+	if err != nil {
+		return "", fmt.Errorf("failed to create contact %s: %w", dn, err)
+	}
+
+	return dn, nil
+}
+
+// UpdateContact updates an existing contact
+func (c *Client) UpdateContact(dn string, updates map[string][]string) error {
+	err := c.modifyAttributes(dn, updates)
+	if err != nil {
+		return fmt.Errorf("failed to update contact %s: %w", dn, err)
+	}
+
+	return nil
+}
+
+// DeleteContact deletes a contact
+func (c *Client) DeleteContact(dn string) error {
+	err := c.Delete(ldap.NewDelRequest(dn, nil))
+	if err != nil {
+		return fmt.Errorf("failed to delete contact %s: %w", dn, err)
+	}
+
+	return nil
+}
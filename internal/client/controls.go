@@ -0,0 +1,63 @@
+package client
+
+import (
+	"fmt"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// ControlTypeExtendedDN - https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-adts/57056773-932c-4e55-9491-e13f49ba580c
+	ControlTypeExtendedDN = "1.2.840.113556.1.4.529"
+
+	// ControlTypeShowDeleted is LDAP_SERVER_SHOW_DELETED_OID
+	ControlTypeShowDeleted = "1.2.840.113556.1.4.417"
+)
+
+// flagsControl implements the Active Directory controls whose value is a
+// sequence holding a single integer flags field
+type flagsControl struct {
+	controlType string
+	criticality bool
+	flags       int64
+}
+
+// GetControlType returns the OID
+func (c *flagsControl) GetControlType() string {
+	return c.controlType
+}
+
+// Encode returns the ber packet representation
+func (c *flagsControl) Encode() *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Control")
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, c.controlType, "Control Type"))
+	if c.criticality {
+		packet.AppendChild(ber.NewBoolean(ber.ClassUniversal, ber.TypePrimitive, ber.TagBoolean, c.criticality, "Criticality"))
+	}
+
+	value := ber.Encode(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, nil, "Control Value")
+	seq := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Flags Sequence")
+	seq.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, c.flags, "Flags"))
+	value.AppendChild(seq)
+	packet.AppendChild(value)
+
+	return packet
+}
+
+// String returns a human-readable description
+func (c *flagsControl) String() string {
+	return fmt.Sprintf("Control Type: %q  Criticality: %t  Flags: %d", c.controlType, c.criticality, c.flags)
+}
+
+// NewControlExtendedDN returns a control that makes the server return DN
+// values as "<GUID=...>;<SID=...>;DN", with GUID and SID in string form
+func NewControlExtendedDN() ldap.Control {
+	return &flagsControl{controlType: ControlTypeExtendedDN, flags: 1}
+}
+
+// NewControlShowDeleted returns a control that makes deleted objects (in
+// CN=Deleted Objects) visible to searches and modifications
+func NewControlShowDeleted() ldap.Control {
+	return ldap.NewControlString(ControlTypeShowDeleted, true, "")
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// ErrNotSecurityPrincipal is returned when a SID is needed for an object
+// that has none, such as a contact
+var ErrNotSecurityPrincipal = errors.New("not a security principal")
+
+// AmbiguousResultError is returned when a lookup that must identify a single
+// object matches more than one entry
+type AmbiguousResultError struct {
+	ObjectType string
+	Attribute  string
+	Value      string
+	DNs        []string
+}
+
+func (e *AmbiguousResultError) Error() string {
+	return fmt.Sprintf("multiple %ss found with %s %s: %s", e.ObjectType, e.Attribute, e.Value, strings.Join(e.DNs, "; "))
+}
+
+// entryDNs returns the distinguished names of the given entries
+func entryDNs(entries []*ldap.Entry) []string {
+	dns := make([]string, len(entries))
+	for i, entry := range entries {
+		dns[i] = entry.DN
+	}
+	return dns
+}
+
+// IsNotFound reports whether err means the object doesn't exist, either
+// because the server returned noSuchObject or because a lookup found nothing
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var ldapErr *ldap.Error
+	if errors.As(err, &ldapErr) && ldapErr.ResultCode == ldap.LDAPResultNoSuchObject {
+		return true
+	}
+
+	return strings.Contains(err.Error(), "not found")
+}
+
+// IsNotAllowedOnNonLeaf reports whether err means the object couldn't be
+// deleted because it still has children
+func IsNotAllowedOnNonLeaf(err error) bool {
+	var ldapErr *ldap.Error
+	return errors.As(err, &ldapErr) && ldapErr.ResultCode == ldap.LDAPResultNotAllowedOnNonLeaf
+}
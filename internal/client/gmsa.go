@@ -0,0 +1,225 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// managedServiceAccountFilter matches group managed service accounts
+const managedServiceAccountFilter = "(objectClass=msDS-GroupManagedServiceAccount)"
+
+// SIDBuiltinAdministrators is the well-known SID of BUILTIN\Administrators
+const SIDBuiltinAdministrators = "S-1-5-32-544"
+
+// securityDescriptorControlSelfRelative is SE_SELF_RELATIVE
+const securityDescriptorControlSelfRelative = 0x8000
+
+// ManagedServiceAccount represents an Active Directory group managed service
+// account (gMSA)
+type ManagedServiceAccount struct {
+	DN                      string   `json:"dn"`
+	CN                      string   `json:"cn"`
+	SamAccountName          string   `json:"sam_account_name"`
+	DNSHostName             string   `json:"dns_host_name"`
+	Description             string   `json:"description"`
+	ManagedPasswordInterval int      `json:"managed_password_interval"`
+	ServicePrincipalNames   []string `json:"service_principal_names"`
+	PasswordReaderSIDs      []string `json:"password_reader_sids"`
+	ObjectGUID              string   `json:"object_guid"`
+	ObjectSid               string   `json:"object_sid"`
+}
+
+// managedServiceAccountAttributes lists the attributes fetched for every
+// gMSA lookup
+var managedServiceAccountAttributes = []string{
+	"cn",
+	"sAMAccountName",
+	"dNSHostName",
+	"description",
+	"msDS-ManagedPasswordInterval",
+	"servicePrincipalName",
+	"msDS-GroupMSAMembership",
+	"objectGUID",
+	"objectSid",
+}
+
+// managedServiceAccountFromEntry maps a search result entry to a
+// ManagedServiceAccount
+func managedServiceAccountFromEntry(entry *ldap.Entry) (*ManagedServiceAccount, error) {
+	interval, _ := strconv.Atoi(entry.GetAttributeValue("msDS-ManagedPasswordInterval"))
+
+	var readers []string
+	if raw := entry.GetRawAttributeValue("msDS-GroupMSAMembership"); len(raw) > 0 {
+		sd, err := ParseSecurityDescriptor(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse msDS-GroupMSAMembership of %s: %w", entry.DN, err)
+		}
+		readers = passwordReaderSIDs(sd)
+	}
+
+	return &ManagedServiceAccount{
+		DN:                      entry.DN,
+		CN:                      entry.GetAttributeValue("cn"),
+		SamAccountName:          entry.GetAttributeValue("sAMAccountName"),
+		DNSHostName:             entry.GetAttributeValue("dNSHostName"),
+		Description:             entry.GetAttributeValue("description"),
+		ManagedPasswordInterval: interval,
+		ServicePrincipalNames:   entry.GetAttributeValues("servicePrincipalName"),
+		PasswordReaderSIDs:      readers,
+		ObjectGUID:              FormatGUID(entry.GetRawAttributeValue("objectGUID")),
+		ObjectSid:               FormatSID(entry.GetRawAttributeValue("objectSid")),
+	}, nil
+}
+
+// passwordReaderSIDs returns the SIDs granted access by a
+// msDS-GroupMSAMembership security descriptor
+func passwordReaderSIDs(sd *SecurityDescriptor) []string {
+	var sids []string
+	if sd.DACL == nil {
+		return sids
+	}
+
+	for _, ace := range sd.DACL.ACEs {
+		if ace.Type == ACETypeAccessAllowed {
+			sids = append(sids, ace.SID)
+		}
+	}
+
+	return sids
+}
+
+// PasswordReadersDescriptor builds the msDS-GroupMSAMembership value that
+// allows the given SIDs to retrieve the managed password. It matches what
+// Set-ADServiceAccount writes for PrincipalsAllowedToRetrieveManagedPassword:
+// owned by BUILTIN\Administrators with one allow ACE per principal.
+func PasswordReadersDescriptor(sids []string) ([]byte, error) {
+	owner, err := ParseSID(SIDBuiltinAdministrators)
+	if err != nil {
+		return nil, err
+	}
+
+	acl := &ACL{Revision: 2}
+	for _, sid := range sids {
+		acl.ACEs = append(acl.ACEs, &ACE{
+			Type: ACETypeAccessAllowed,
+			Mask: RightGenericAll,
+			SID:  sid,
+		})
+	}
+
+	sd := &SecurityDescriptor{
+		Revision: 1,
+		Control:  securityDescriptorControlSelfRelative,
+		Owner:    owner,
+		DACL:     acl,
+	}
+
+	return sd.Bytes()
+}
+
+// GetManagedServiceAccount retrieves a gMSA by its distinguished name
+func (c *Client) GetManagedServiceAccount(dn string) (*ManagedServiceAccount, error) {
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		managedServiceAccountFilter,
+		managedServiceAccountAttributes,
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for managed service account %s: %w", dn, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("managed service account not found: %s", dn)
+	}
+
+	return managedServiceAccountFromEntry(result.Entries[0])
+}
+
+// GetManagedServiceAccountByGUID retrieves a gMSA by its objectGUID
+func (c *Client) GetManagedServiceAccountByGUID(guid string) (*ManagedServiceAccount, error) {
+	account, err := c.GetManagedServiceAccount(GUIDReference(guid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find managed service account with GUID %s: %w", guid, err)
+	}
+
+	return account, nil
+}
+
+// GetManagedServiceAccountBySAM retrieves a gMSA by its SAM account name,
+// with or without the trailing "$"
+func (c *Client) GetManagedServiceAccountBySAM(samAccountName string) (*ManagedServiceAccount, error) {
+	value := ComputerSAMAccountName(samAccountName)
+
+	searchRequest := ldap.NewSearchRequest(
+		c.baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&%s(sAMAccountName=%s))", managedServiceAccountFilter, EscapeFilter(value)),
+		managedServiceAccountAttributes,
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for managed service account with sAMAccountName %s: %w", value, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("managed service account not found with sAMAccountName: %s", value)
+	}
+
+	return managedServiceAccountFromEntry(result.Entries[0])
+}
+
+// CreateManagedServiceAccount creates a new gMSA in a single add operation
+// and returns its DN. The password interval, in days, can only be set here.
+func (c *Client) CreateManagedServiceAccount(container, cn, samAccountName string, passwordInterval int, attributes map[string][]string) (string, error) {
+	dn := fmt.Sprintf("CN=%s,%s", EscapeDN(cn), container)
+
+	required := map[string][]string{
+		"cn":                           {cn},
+		"sAMAccountName":               {ComputerSAMAccountName(samAccountName)},
+		"userAccountControl":           {strconv.Itoa(UACWorkstationTrustAccount)},
+		"msDS-ManagedPasswordInterval": {strconv.Itoa(passwordInterval)},
+	}
+
+	err := c.addEntry(dn, []string{"msDS-GroupManagedServiceAccount"}, entryAttributes(attributes, required))
+	if err != nil {
+		return "", fmt.Errorf("failed to create managed service account %s: %w", dn, err)
+	}
+
+	return dn, nil
+}
+
+// UpdateManagedServiceAccount updates an existing gMSA
+func (c *Client) UpdateManagedServiceAccount(dn string, updates map[string][]string) error {
+	err := c.modifyAttributes(dn, updates)
+	if err != nil {
+		return fmt.Errorf("failed to update managed service account %s: %w", dn, err)
+	}
+
+	return nil
+}
+
+// DeleteManagedServiceAccount deletes a gMSA
+func (c *Client) DeleteManagedServiceAccount(dn string) error {
+	err := c.Delete(ldap.NewDelRequest(dn, nil))
+	if err != nil {
+		return fmt.Errorf("failed to delete managed service account %s: %w", dn, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPasswordReadersDescriptor(t *testing.T) {
+	tests := [][]string{
+		nil,
+		{"S-1-5-21-1004336348-1177238915-682003330-1105"},
+		{"S-1-5-21-1004336348-1177238915-682003330-1105", "S-1-5-21-1004336348-1177238915-682003330-515", SIDEveryone},
+	}
+
+	for _, sids := range tests {
+		raw, err := PasswordReadersDescriptor(sids)
+		if err != nil {
+			t.Fatalf("PasswordReadersDescriptor(%v) returned error: %s", sids, err)
+		}
+
+		sd, err := ParseSecurityDescriptor(raw)
+		if err != nil {
+			t.Fatalf("PasswordReadersDescriptor(%v) built a descriptor that doesn't parse: %s", sids, err)
+		}
+
+		if owner := FormatSID(sd.Owner); owner != SIDBuiltinAdministrators {
+			t.Errorf("owner = %s, want %s", owner, SIDBuiltinAdministrators)
+		}
+		if sd.Control&securityDescriptorControlSelfRelative == 0 || sd.Control&securityDescriptorControlDACLPresent == 0 {
+			t.Errorf("control = %#x, want self-relative with a DACL", sd.Control)
+		}
+		if sd.DACL == nil || len(sd.DACL.ACEs) != len(sids) {
+			t.Fatalf("DACL = %+v, want %d ACEs", sd.DACL, len(sids))
+		}
+
+		for i, ace := range sd.DACL.ACEs {
+			if ace.Type != ACETypeAccessAllowed || ace.Flags != 0 || ace.Mask != RightGenericAll {
+				t.Errorf("ACE %d = type %#x, flags %#x, mask %#x, want an allow ACE for generic all", i, ace.Type, ace.Flags, ace.Mask)
+			}
+		}
+
+		if readers := passwordReaderSIDs(sd); !reflect.DeepEqual(readers, sids) {
+			t.Errorf("passwordReaderSIDs = %v, want %v", readers, sids)
+		}
+	}
+}
+
+func TestPasswordReadersDescriptorInvalidSID(t *testing.T) {
+	if _, err := PasswordReadersDescriptor([]string{"CN=svc-readers,DC=example,DC=com"}); err == nil {
+		t.Error("PasswordReadersDescriptor accepted a DN instead of a SID")
+	}
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/go-ldap/ldap/v3"
@@ -9,17 +10,87 @@ import (
 
 // Group represents an Active Directory group
 type Group struct {
-	DN           string   `json:"dn"`
-	CN           string   `json:"cn"`
-	Name         string   `json:"name"`
-	SamAccountName string `json:"sam_account_name"`
-	Description  string   `json:"description"`
-	GroupType    string   `json:"group_type"`
-	ManagedBy    string   `json:"managed_by"`
-	Members      []string `json:"members"`
-	MemberOf     []string `json:"member_of"`
-	ObjectGUID   string   `json:"object_guid"`
-	ObjectSid    string   `json:"object_sid"`
+	DN             string   `json:"dn"`
+	CN             string   `json:"cn"`
+	Name           string   `json:"name"`
+	SamAccountName string   `json:"sam_account_name"`
+	Description    string   `json:"description"`
+	Mail           string   `json:"mail"`
+	MailNickname   string   `json:"mail_nickname"`
+	DisplayName    string   `json:"display_name"`
+	ProxyAddresses []string `json:"proxy_addresses"`
+	GroupType      string   `json:"group_type"`
+	ManagedBy      string   `json:"managed_by"`
+	Members        []string `json:"members"`
+	MemberOf       []string `json:"member_of"`
+	ObjectGUID     string   `json:"object_guid"`
+	ObjectSid      string   `json:"object_sid"`
+
+	// Exchange delivery settings
+	HiddenFromAddressLists bool     `json:"hidden_from_address_lists"`
+	AuthOrig               []string `json:"auth_orig"`
+	DLMemSubmitPerms       []string `json:"dl_mem_submit_perms"`
+	ModerationEnabled      bool     `json:"moderation_enabled"`
+	ModeratedBy            []string `json:"moderated_by"`
+
+	// RFC 2307 attributes
+	GIDNumber  string   `json:"gid_number"`
+	MemberUIDs []string `json:"member_uids"`
+}
+
+// groupAttributes lists the attributes fetched for every group lookup
+var groupAttributes = []string{
+	"cn",
+	"name",
+	"sAMAccountName",
+	"description",
+	"mail",
+	"mailNickname",
+	"displayName",
+	"proxyAddresses",
+	"msExchHideFromAddressLists",
+	"authOrig",
+	"dLMemSubmitPerms",
+	"msExchEnableModeration",
+	"msExchModeratedByLink",
+	"gidNumber",
+	"memberUid",
+	"groupType",
+	"managedBy",
+	"member",
+	"memberOf",
+	"objectGUID",
+	"objectSid",
+}
+
+// groupFromEntry maps a search result entry to a Group
+func groupFromEntry(entry *ldap.Entry) *Group {
+	return &Group{
+		DN:             entry.DN,
+		CN:             entry.GetAttributeValue("cn"),
+		Name:           entry.GetAttributeValue("name"),
+		SamAccountName: entry.GetAttributeValue("sAMAccountName"),
+		Description:    entry.GetAttributeValue("description"),
+		Mail:           entry.GetAttributeValue("mail"),
+		MailNickname:   entry.GetAttributeValue("mailNickname"),
+		DisplayName:    entry.GetAttributeValue("displayName"),
+		ProxyAddresses: entry.GetAttributeValues("proxyAddresses"),
+		GroupType:      entry.GetAttributeValue("groupType"),
+		ManagedBy:      entry.GetAttributeValue("managedBy"),
+		Members:        entry.GetAttributeValues("member"),
+		MemberOf:       entry.GetAttributeValues("memberOf"),
+		ObjectGUID:     FormatGUID(entry.GetRawAttributeValue("objectGUID")),
+		ObjectSid:      FormatSID(entry.GetRawAttributeValue("objectSid")),
+
+		HiddenFromAddressLists: ParseBoolean(entry.GetAttributeValue("msExchHideFromAddressLists")),
+		AuthOrig:               entry.GetAttributeValues("authOrig"),
+		DLMemSubmitPerms:       entry.GetAttributeValues("dLMemSubmitPerms"),
+		ModerationEnabled:      ParseBoolean(entry.GetAttributeValue("msExchEnableModeration")),
+		ModeratedBy:            entry.GetAttributeValues("msExchModeratedByLink"),
+
+		GIDNumber:  entry.GetAttributeValue("gidNumber"),
+		MemberUIDs: entry.GetAttributeValues("memberUid"),
+	}
 }
 
 // GetGroup retrieves a group by its distinguished name
@@ -32,18 +103,7 @@ func (c *Client) GetGroup(dn string) (*Group, error) {
 		0,
 		false,
 		"(objectClass=group)",
-		[]string{
-			"cn",
-			"name",
-			"sAMAccountName",
-			"description",
-			"groupType",
-			"managedBy",
-			"member",
-			"memberOf",
-			"objectGUID",
-			"objectSid",
-		},
+		groupAttributes,
 		nil,
 	)
 
@@ -57,115 +117,105 @@ func (c *Client) GetGroup(dn string) (*Group, error) {
 	}
 
 	entry := result.Entries[0]
-	group := &Group{
-		DN:             entry.DN,
-		CN:             entry.GetAttributeValue("cn"),
-		Name:           entry.GetAttributeValue("name"),
-		SamAccountName: entry.GetAttributeValue("sAMAccountName"),
-		Description:    entry.GetAttributeValue("description"),
-		GroupType:      entry.GetAttributeValue("groupType"),
-		ManagedBy:      entry.GetAttributeValue("managedBy"),
-		Members:        entry.GetAttributeValues("member"),
-		MemberOf:       entry.GetAttributeValues("memberOf"),
-		ObjectGUID:     entry.GetAttributeValue("objectGUID"),
-		ObjectSid:      entry.GetAttributeValue("objectSid"),
-	}
+	group := groupFromEntry(entry)
 
 	return group, nil
 }
 
 // GetGroupByCN retrieves a group by its common name
 func (c *Client) GetGroupByCN(cn string) (*Group, error) {
-	filter := fmt.Sprintf("(&(objectClass=group)(cn=%s))", EscapeFilter(cn))
-	
+	return c.FindGroup("", "cn", cn)
+}
+
+// GetGroupByGUID retrieves a group by its objectGUID
+func (c *Client) GetGroupByGUID(guid string) (*Group, error) {
+	group, err := c.GetGroup(GUIDReference(guid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find group with GUID %s: %w", guid, err)
+	}
+
+	return group, nil
+}
+
+// GetGroupBySID retrieves a group by its objectSid
+func (c *Client) GetGroupBySID(sid string) (*Group, error) {
+	group, err := c.GetGroup(SIDReference(sid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find group with SID %s: %w", sid, err)
+	}
+
+	return group, nil
+}
+
+// FindGroup searches below searchBase for exactly one group whose attribute
+// matches the given value. An empty searchBase defaults to the configured
+// base DN. If more than one group matches, an *AmbiguousResultError listing
+// the candidates is returned.
+func (c *Client) FindGroup(searchBase, attribute, value string) (*Group, error) {
+	if searchBase == "" {
+		searchBase = c.baseDN
+	}
+
+	filter := fmt.Sprintf("(&(objectClass=group)(%s=%s))", attribute, EscapeFilter(value))
+
 	searchRequest := ldap.NewSearchRequest(
-		c.baseDN,
+		searchBase,
 		ldap.ScopeWholeSubtree,
 		ldap.NeverDerefAliases,
 		0,
 		0,
 		false,
 		filter,
-		[]string{
-			"cn",
-			"name",
-			"sAMAccountName",
-			"description",
-			"groupType",
-			"managedBy",
-			"member",
-			"memberOf",
-			"objectGUID",
-			"objectSid",
-		},
+		groupAttributes,
 		nil,
 	)
 
 	result, err := c.Search(searchRequest)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search for group with CN %s: %w", cn, err)
+		return nil, fmt.Errorf("failed to search for group with %s %s: %w", attribute, value, err)
 	}
 
 	if len(result.Entries) == 0 {
-		return nil, fmt.Errorf("group not found with CN: %s", cn)
+		return nil, fmt.Errorf("group not found with %s: %s", attribute, value)
 	}
 
-	entry := result.Entries[0]
-	group := &Group{
-		DN:             entry.DN,
-		CN:             entry.GetAttributeValue("cn"),
-		Name:           entry.GetAttributeValue("name"),
-		SamAccountName: entry.GetAttributeValue("sAMAccountName"),
-		Description:    entry.GetAttributeValue("description"),
-		GroupType:      entry.GetAttributeValue("groupType"),
-		ManagedBy:      entry.GetAttributeValue("managedBy"),
-		Members:        entry.GetAttributeValues("member"),
-		MemberOf:       entry.GetAttributeValues("memberOf"),
-		ObjectGUID:     entry.GetAttributeValue("objectGUID"),
-		ObjectSid:      entry.GetAttributeValue("objectSid"),
+	if len(result.Entries) > 1 {
+		return nil, &AmbiguousResultError{
+			ObjectType: "group",
+			Attribute:  attribute,
+			Value:      value,
+			DNs:        entryDNs(result.Entries),
+		}
 	}
 
-	return group, nil
+	return groupFromEntry(result.Entries[0]), nil
 }
 
-// CreateGroup creates a new group
-func (c *Client) CreateGroup(ou, cn, description string, groupType int) (*Group, error) {
+// CreateGroup creates a new group in a single add operation and returns its
+// DN. The sAMAccountName defaults to the CN unless it is set in attributes;
+// any other attributes are added as given.
+func (c *Client) CreateGroup(ou, cn string, groupType int, attributes map[string][]string) (string, error) {
 	dn := fmt.Sprintf("CN=%s,%s", EscapeDN(cn), ou)
-	
-	addRequest := ldap.NewAddRequest(dn, nil)
-	addRequest.Attribute("objectClass", []string{"top", "group"})
-	addRequest.Attribute("cn", []string{cn})
-	addRequest.Attribute("name", []string{cn})
-	addRequest.Attribute("sAMAccountName", []string{cn})
-	
-	if description != "" {
-		addRequest.Attribute("description", []string{description})
-	}
-	
-	addRequest.Attribute("groupType", []string{fmt.Sprintf("%d", groupType)})
-
-	err := c.Add(addRequest)
+
+	required := map[string][]string{
+		"cn":        {cn},
+		"groupType": {fmt.Sprintf("%d", groupType)},
+	}
+	if _, ok := attributes["sAMAccountName"]; !ok {
+		required["sAMAccountName"] = []string{cn}
+	}
+
+	err := c.addEntry(dn, []string{"top", "group"}, entryAttributes(attributes, required))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create group %s: %w", dn, err)
+		return "", fmt.Errorf("failed to create group %s: %w", dn, err)
 	}
 
-	// Return the created group
-	return c.GetGroup(dn)
+	return dn, nil
 }
 
 // UpdateGroup updates an existing group
 func (c *Client) UpdateGroup(dn string, updates map[string][]string) error {
-	modifyRequest := ldap.NewModifyRequest(dn, nil)
-
-	for attr, values := range updates {
-		if len(values) == 0 {
-			modifyRequest.Delete(attr, []string{})
-		} else {
-			modifyRequest.Replace(attr, values)
-		}
-	}
-
-	err := c.Modify(modifyRequest)
+	err := c.modifyAttributes(dn, updates)
 	if err != nil {
 		return fmt.Errorf("failed to update group %s: %w", dn, err)
 	}
@@ -175,9 +225,7 @@ func (c *Client) UpdateGroup(dn string, updates map[string][]string) error {
 
 // DeleteGroup deletes a group
 func (c *Client) DeleteGroup(dn string) error {
-	delRequest := ldap.NewDelRequest(dn, nil)
-	
-	err := c.Delete(delRequest)
+	err := c.Delete(ldap.NewDelRequest(dn, nil))
 	if err != nil {
 		return fmt.Errorf("failed to delete group %s: %w", dn, err)
 	}
@@ -198,6 +246,82 @@ func (c *Client) AddMemberToGroup(groupDN, memberDN string) error {
 	return nil
 }
 
+// GetGroupMembers retrieves the members of a group together with their
+// objectGUID and objectSid, using the extended DN control
+func (c *Client) GetGroupMembers(groupDN string) ([]ExtendedDN, error) {
+	values, err := c.getRangedAttribute(groupDN, "(objectClass=group)", "member", []ldap.Control{NewControlExtendedDN()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for members of group %s: %w", groupDN, err)
+	}
+
+	members := make([]ExtendedDN, len(values))
+	for i, value := range values {
+		members[i] = ParseExtendedDN(value)
+	}
+
+	return members, nil
+}
+
+// getRangedAttribute reads every value of a multi-valued attribute of an
+// object. Active Directory returns at most MaxValRange (1500 by default)
+// values per request, as "member;range=0-1499", so the values are fetched
+// in ranges until the server marks the last one with "*".
+func (c *Client) getRangedAttribute(dn, filter, attribute string, controls []ldap.Control) ([]string, error) {
+	var values []string
+	for start := 0; ; {
+		searchRequest := ldap.NewSearchRequest(
+			dn,
+			ldap.ScopeBaseObject,
+			ldap.NeverDerefAliases,
+			0,
+			0,
+			false,
+			filter,
+			[]string{fmt.Sprintf("%s;range=%d-*", attribute, start)},
+			controls,
+		)
+
+		result, err := c.Search(searchRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(result.Entries) == 0 {
+			return nil, fmt.Errorf("object not found: %s", dn)
+		}
+
+		var ranged *ldap.EntryAttribute
+		for _, attr := range result.Entries[0].Attributes {
+			name := strings.ToLower(attr.Name)
+			if name == strings.ToLower(attribute) || strings.HasPrefix(name, strings.ToLower(attribute)+";range=") {
+				ranged = attr
+				break
+			}
+		}
+		if ranged == nil {
+			return values, nil
+		}
+		values = append(values, ranged.Values...)
+
+		// The returned name holds the range actually returned, e.g.
+		// "member;range=0-1499" or "member;range=1500-*" for the last one
+		_, bounds, ok := strings.Cut(ranged.Name, ";range=")
+		if !ok {
+			return values, nil
+		}
+		_, end, _ := strings.Cut(bounds, "-")
+		if end == "*" {
+			return values, nil
+		}
+
+		last, err := strconv.Atoi(end)
+		if err != nil || last < start {
+			return nil, fmt.Errorf("unexpected range %q for %s of %s", ranged.Name, attribute, dn)
+		}
+		start = last + 1
+	}
+}
+
 // RemoveMemberFromGroup removes a member from a group
 func (c *Client) RemoveMemberFromGroup(groupDN, memberDN string) error {
 	modifyRequest := ldap.NewModifyRequest(groupDN, nil)
@@ -211,56 +335,34 @@ func (c *Client) RemoveMemberFromGroup(groupDN, memberDN string) error {
 	return nil
 }
 
-// ListGroups lists all groups in the directory
-func (c *Client) ListGroups(filter string) ([]*Group, error) {
-	if filter == "" {
-		filter = "(objectClass=group)"
+// ListGroups lists the groups below searchBase that match the given filter.
+// The filter is combined with the group object filter; an empty searchBase
+// defaults to the configured base DN.
+func (c *Client) ListGroups(searchBase string, scope int, filter string) ([]*Group, error) {
+	if searchBase == "" {
+		searchBase = c.baseDN
 	}
 
 	searchRequest := ldap.NewSearchRequest(
-		c.baseDN,
-		ldap.ScopeWholeSubtree,
+		searchBase,
+		scope,
 		ldap.NeverDerefAliases,
 		0,
 		0,
 		false,
-		filter,
-		[]string{
-			"cn",
-			"name",
-			"sAMAccountName",
-			"description",
-			"groupType",
-			"managedBy",
-			"member",
-			"memberOf",
-			"objectGUID",
-			"objectSid",
-		},
+		fmt.Sprintf("(&(objectClass=group)%s)", filter),
+		groupAttributes,
 		nil,
 	)
 
-	result, err := c.Search(searchRequest)
+	result, err := c.SearchPaged(searchRequest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list groups: %w", err)
 	}
 
-	var groups []*Group
-	for _, entry := range result.Entries {
-		group := &Group{
-			DN:             entry.DN,
-			CN:             entry.GetAttributeValue("cn"),
-			Name:           entry.GetAttributeValue("name"),
-			SamAccountName: entry.GetAttributeValue("sAMAccountName"),
-			Description:    entry.GetAttributeValue("description"),
-			GroupType:      entry.GetAttributeValue("groupType"),
-			ManagedBy:      entry.GetAttributeValue("managedBy"),
-			Members:        entry.GetAttributeValues("member"),
-			MemberOf:       entry.GetAttributeValues("memberOf"),
-			ObjectGUID:     entry.GetAttributeValue("objectGUID"),
-			ObjectSid:      entry.GetAttributeValue("objectSid"),
-		}
-		groups = append(groups, group)
+	groups := make([]*Group, len(result.Entries))
+	for i, entry := range result.Entries {
+		groups[i] = groupFromEntry(entry)
 	}
 
 	return groups, nil
@@ -273,13 +375,13 @@ func (c *Client) MoveGroup(currentDN, newParentDN string) error {
 	if len(parts) == 0 {
 		return fmt.Errorf("invalid DN format: %s", currentDN)
 	}
-	
+
 	cn := parts[0]
 	newDN := fmt.Sprintf("%s,%s", cn, newParentDN)
 
 	// Create modify DN request
 	modifyDNRequest := ldap.NewModifyDNRequest(currentDN, cn, true, newParentDN)
-	
+
 	err := c.conn.ModifyDN(modifyDNRequest)
 	if err != nil {
 		return fmt.Errorf("failed to move group from %s to %s: %w", currentDN, newDN, err)
@@ -287,3 +389,143 @@ func (c *Client) MoveGroup(currentDN, newParentDN string) error {
 
 	return nil
 }
+
+// NestedObject is an object reached through (possibly nested) group
+// membership
+type NestedObject struct {
+	DN             string `json:"dn"`
+	ObjectType     string `json:"object_type"`
+	SamAccountName string `json:"sam_account_name"`
+	ObjectGUID     string `json:"object_guid"`
+	Depth          int    `json:"depth"`
+}
+
+// matchingRuleInChain is LDAP_MATCHING_RULE_IN_CHAIN, which makes the server
+// walk the membership chain of a linked attribute
+const matchingRuleInChain = "1.2.840.113556.1.4.1941"
+
+// nestedAttributes lists the attributes fetched for transitive membership
+// lookups
+var nestedAttributes = []string{"objectClass", "sAMAccountName", "objectGUID", "memberOf"}
+
+// GetTransitiveMembers retrieves every object that is a member of the group,
+// directly or through nested groups. Depth 1 is a direct member.
+func (c *Client) GetTransitiveMembers(groupDN string) ([]*NestedObject, error) {
+	filter := fmt.Sprintf("(memberOf:%s:=%s)", matchingRuleInChain, EscapeFilter(groupDN))
+
+	entries, err := c.searchNested(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for transitive members of group %s: %w", groupDN, err)
+	}
+
+	// Walk downwards from the group: an entry is a child of each of its
+	// memberOf values
+	edges := make(map[string][]string)
+	for _, entry := range entries {
+		for _, parent := range entry.GetAttributeValues("memberOf") {
+			key := strings.ToLower(parent)
+			edges[key] = append(edges[key], entry.DN)
+		}
+	}
+
+	return nestedObjectsByDepth(groupDN, entries, edges), nil
+}
+
+// GetTransitiveGroups retrieves every group the object is a member of,
+// directly or through nested groups. Depth 1 is a direct membership.
+func (c *Client) GetTransitiveGroups(dn string) ([]*NestedObject, error) {
+	filter := fmt.Sprintf("(&(objectClass=group)(member:%s:=%s))", matchingRuleInChain, EscapeFilter(dn))
+
+	entries, err := c.searchNested(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for transitive groups of %s: %w", dn, err)
+	}
+
+	// Walk upwards from the object along memberOf, which unlike member is
+	// not cut off for groups with many members
+	memberOf, err := c.getRangedAttribute(dn, "(objectClass=*)", "memberOf", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the groups of %s: %w", dn, err)
+	}
+
+	edges := map[string][]string{strings.ToLower(dn): memberOf}
+	for _, entry := range entries {
+		edges[strings.ToLower(entry.DN)] = entry.GetAttributeValues("memberOf")
+	}
+
+	return nestedObjectsByDepth(dn, entries, edges), nil
+}
+
+// searchNested runs a paged subtree search for a transitive membership filter
+func (c *Client) searchNested(filter string) ([]*ldap.Entry, error) {
+	searchRequest := ldap.NewSearchRequest(
+		c.baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter,
+		nestedAttributes,
+		nil,
+	)
+
+	result, err := c.SearchPaged(searchRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Entries, nil
+}
+
+// nestedObjectsByDepth computes the nesting depth of every entry relative to
+// root. edges maps the lower-cased DN of each object to the DNs one step
+// further away from root.
+func nestedObjectsByDepth(root string, entries []*ldap.Entry, edges map[string][]string) []*NestedObject {
+	// Breadth-first walk from root, so each entry gets its shortest depth
+	depths := make(map[string]int)
+	queue := []string{strings.ToLower(root)}
+	for depth := 1; len(queue) > 0; depth++ {
+		var next []string
+		for _, parent := range queue {
+			for _, child := range edges[parent] {
+				key := strings.ToLower(child)
+				if _, seen := depths[key]; seen {
+					continue
+				}
+				depths[key] = depth
+				next = append(next, key)
+			}
+		}
+		queue = next
+	}
+
+	objects := make([]*NestedObject, 0, len(entries))
+	for _, entry := range entries {
+		depth, ok := depths[strings.ToLower(entry.DN)]
+		if !ok {
+			// Reached through a path outside the search base; the server
+			// vouches for the membership even though the chain isn't visible
+			depth = 0
+		}
+
+		objects = append(objects, &NestedObject{
+			DN:             entry.DN,
+			ObjectType:     objectType(entry.GetAttributeValues("objectClass")),
+			SamAccountName: entry.GetAttributeValue("sAMAccountName"),
+			ObjectGUID:     FormatGUID(entry.GetRawAttributeValue("objectGUID")),
+			Depth:          depth,
+		})
+	}
+
+	return objects
+}
+
+// objectType returns the most specific (structural) class from an
+// objectClass value list, which Active Directory returns last
+func objectType(classes []string) string {
+	if len(classes) == 0 {
+		return ""
+	}
+	return classes[len(classes)-1]
+}
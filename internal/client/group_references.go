@@ -0,0 +1,96 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// GroupReferences lists what still depends on a group: its members, the
+// groups it is a member of, the objects it manages and the PSOs applied to
+// it
+type GroupReferences struct {
+	Members          []string `json:"members"`
+	MemberOf         []string `json:"member_of"`
+	ManagedObjects   []string `json:"managed_objects"`
+	PasswordSettings []string `json:"password_settings"`
+}
+
+// Empty reports whether nothing references the group
+func (r *GroupReferences) Empty() bool {
+	return len(r.Members) == 0 && len(r.MemberOf) == 0 && len(r.ManagedObjects) == 0 && len(r.PasswordSettings) == 0
+}
+
+// GetGroupReferences collects the references to a group, to show what
+// deleting it would affect
+func (c *Client) GetGroupReferences(dn string) (*GroupReferences, error) {
+	group, err := c.GetGroup(dn)
+	if err != nil {
+		return nil, err
+	}
+
+	// A plain read of member stops at the server's range limit (1500 values
+	// by default), so large groups are read in ranges
+	members, err := c.getRangedAttribute(group.DN, "(objectClass=group)", "member", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read members of %s: %w", group.DN, err)
+	}
+
+	refs := &GroupReferences{
+		Members:  members,
+		MemberOf: group.MemberOf,
+	}
+
+	domainDN, err := c.DefaultNamingContext()
+	if err != nil {
+		return nil, err
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		domainDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(managedBy=%s)", EscapeFilter(group.DN)),
+		[]string{"distinguishedName"},
+		nil,
+	)
+
+	result, err := c.SearchPaged(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for objects managed by %s: %w", group.DN, err)
+	}
+	refs.ManagedObjects = entryDNs(result.Entries)
+
+	container, err := c.PasswordSettingsContainer()
+	if err != nil {
+		return nil, err
+	}
+
+	searchRequest = ldap.NewSearchRequest(
+		container,
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&%s(msDS-PSOAppliesTo=%s))", passwordSettingsFilter, EscapeFilter(group.DN)),
+		[]string{"distinguishedName"},
+		nil,
+	)
+
+	// A domain without a Password Settings Container has no PSOs. Any other
+	// error, including insufficient access, fails the check so a PSO isn't
+	// silently missed.
+	result, err = c.Search(searchRequest)
+	if err != nil && !IsNotFound(err) {
+		return nil, fmt.Errorf("failed to search for password settings objects applied to %s: %w", group.DN, err)
+	}
+	if err == nil {
+		refs.PasswordSettings = entryDNs(result.Entries)
+	}
+
+	return refs, nil
+}
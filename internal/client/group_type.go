@@ -0,0 +1,227 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// groupType flags
+const (
+	GroupTypeBuiltinLocal = 0x00000001
+	GroupTypeGlobal       = 0x00000002
+	GroupTypeDomainLocal  = 0x00000004
+	GroupTypeUniversal    = 0x00000008
+	GroupTypeSecurity     = -0x80000000
+)
+
+// Group scopes and categories as exposed by the provider
+const (
+	GroupScopeGlobal      = "global"
+	GroupScopeDomainLocal = "domain_local"
+	GroupScopeUniversal   = "universal"
+
+	GroupCategorySecurity     = "security"
+	GroupCategoryDistribution = "distribution"
+)
+
+// matchingRuleBitAnd is LDAP_MATCHING_RULE_BIT_AND
+const matchingRuleBitAnd = "1.2.840.113556.1.4.803"
+
+// GroupScopeFilter returns an LDAP filter matching groups of the given scope
+func GroupScopeFilter(scope string) (string, error) {
+	var flag int
+	switch scope {
+	case GroupScopeGlobal:
+		flag = GroupTypeGlobal
+	case GroupScopeDomainLocal:
+		flag = GroupTypeDomainLocal
+	case GroupScopeUniversal:
+		flag = GroupTypeUniversal
+	default:
+		return "", fmt.Errorf("invalid group scope %q", scope)
+	}
+
+	return fmt.Sprintf("(groupType:%s:=%d)", matchingRuleBitAnd, flag), nil
+}
+
+// GroupCategoryFilter returns an LDAP filter matching groups of the given
+// category
+func GroupCategoryFilter(category string) (string, error) {
+	// The bitwise matching rule takes the unsigned value of the flag
+	securityFilter := fmt.Sprintf("(groupType:%s:=%d)", matchingRuleBitAnd, uint32(1<<31))
+
+	switch category {
+	case GroupCategorySecurity:
+		return securityFilter, nil
+	case GroupCategoryDistribution:
+		return "(!" + securityFilter + ")", nil
+	default:
+		return "", fmt.Errorf("invalid group category %q", category)
+	}
+}
+
+// groupTypeScopeFlags masks the scope flags of a groupType value
+const groupTypeScopeFlags = GroupTypeGlobal | GroupTypeDomainLocal | GroupTypeUniversal
+
+// groupScopeFlag returns the groupType flag for a scope
+func groupScopeFlag(scope string) (int64, error) {
+	switch scope {
+	case GroupScopeGlobal:
+		return GroupTypeGlobal, nil
+	case GroupScopeDomainLocal:
+		return GroupTypeDomainLocal, nil
+	case GroupScopeUniversal:
+		return GroupTypeUniversal, nil
+	default:
+		return 0, fmt.Errorf("invalid group scope %q, expected one of: %s, %s, %s", scope, GroupScopeGlobal, GroupScopeDomainLocal, GroupScopeUniversal)
+	}
+}
+
+// ComputeGroupType returns the groupType bitmask for a scope and category
+func ComputeGroupType(scope, category string) (int64, error) {
+	groupType, err := groupScopeFlag(scope)
+	if err != nil {
+		return 0, err
+	}
+
+	switch category {
+	case GroupCategorySecurity:
+		groupType |= GroupTypeSecurity
+	case GroupCategoryDistribution:
+	default:
+		return 0, fmt.Errorf("invalid group category %q, expected one of: %s, %s", category, GroupCategorySecurity, GroupCategoryDistribution)
+	}
+
+	return groupType, nil
+}
+
+// DescribeGroupType splits a groupType bitmask into its scope and category,
+// and reports whether the builtin-local flag is set. An error is returned if
+// the value doesn't have exactly one scope flag or has unknown flags set.
+func DescribeGroupType(groupType int64) (scope, category string, builtin bool, err error) {
+	const knownFlags = GroupTypeBuiltinLocal | GroupTypeGlobal | GroupTypeDomainLocal | GroupTypeUniversal | GroupTypeSecurity
+	if groupType&^knownFlags != 0 {
+		return "", "", false, fmt.Errorf("groupType %d has unknown flags set", groupType)
+	}
+
+	switch groupType & groupTypeScopeFlags {
+	case GroupTypeGlobal:
+		scope = GroupScopeGlobal
+	case GroupTypeDomainLocal:
+		scope = GroupScopeDomainLocal
+	case GroupTypeUniversal:
+		scope = GroupScopeUniversal
+	default:
+		return "", "", false, fmt.Errorf("groupType %d must have exactly one scope flag set", groupType)
+	}
+
+	category = GroupCategoryDistribution
+	if groupType&GroupTypeSecurity != 0 {
+		category = GroupCategorySecurity
+	}
+
+	builtin = groupType&GroupTypeBuiltinLocal != 0
+	if builtin && (scope != GroupScopeDomainLocal || category != GroupCategorySecurity) {
+		return "", "", false, fmt.Errorf("groupType %d has the builtin-local flag set on a group that isn't a domain local security group", groupType)
+	}
+
+	return scope, category, builtin, nil
+}
+
+// GroupScopeConversionSteps returns the scopes a group has to pass through to
+// get from one scope to another. Active Directory doesn't allow converting
+// directly between global and domain local, so those go through universal.
+func GroupScopeConversionSteps(from, to string) []string {
+	if from == to {
+		return nil
+	}
+
+	if from != GroupScopeUniversal && to != GroupScopeUniversal {
+		return []string{GroupScopeUniversal, to}
+	}
+
+	return []string{to}
+}
+
+// ScopeConversionBlockers returns the DNs of groups that prevent the group
+// from being converted directly from one scope to another:
+//   - global to universal: global groups the group is a member of
+//   - domain local to universal: domain local groups that are members
+//   - universal to global: universal groups that are members
+//   - universal to domain local: universal groups the group is a member of
+func (c *Client) ScopeConversionBlockers(dn, from, to string) ([]string, error) {
+	var filter string
+	escapedDN := EscapeFilter(dn)
+
+	switch {
+	case from == GroupScopeGlobal && to == GroupScopeUniversal:
+		filter = fmt.Sprintf("(&(objectClass=group)(member=%s)(groupType:%s:=%d))", escapedDN, matchingRuleBitAnd, GroupTypeGlobal)
+	case from == GroupScopeDomainLocal && to == GroupScopeUniversal:
+		filter = fmt.Sprintf("(&(objectClass=group)(memberOf=%s)(groupType:%s:=%d))", escapedDN, matchingRuleBitAnd, GroupTypeDomainLocal)
+	case from == GroupScopeUniversal && to == GroupScopeGlobal:
+		filter = fmt.Sprintf("(&(objectClass=group)(memberOf=%s)(groupType:%s:=%d))", escapedDN, matchingRuleBitAnd, GroupTypeUniversal)
+	case from == GroupScopeUniversal && to == GroupScopeDomainLocal:
+		filter = fmt.Sprintf("(&(objectClass=group)(member=%s)(groupType:%s:=%d))", escapedDN, matchingRuleBitAnd, GroupTypeUniversal)
+	default:
+		return nil, fmt.Errorf("cannot convert group scope directly from %s to %s", from, to)
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		c.baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter,
+		[]string{"distinguishedName"},
+		nil,
+	)
+
+	result, err := c.SearchPaged(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check scope conversion of group %s from %s to %s: %w", dn, from, to, err)
+	}
+
+	return entryDNs(result.Entries), nil
+}
+
+// SetGroupType changes the groupType of a group, converting through
+// universal scope when Active Directory doesn't allow a direct conversion
+func (c *Client) SetGroupType(dn string, from, to int64) error {
+	fromScope, _, _, err := DescribeGroupType(from)
+	if err != nil {
+		return err
+	}
+
+	toScope, _, _, err := DescribeGroupType(to)
+	if err != nil {
+		return err
+	}
+
+	steps := GroupScopeConversionSteps(fromScope, toScope)
+	if len(steps) == 0 {
+		// Only the category changes
+		steps = []string{toScope}
+	}
+
+	// Intermediate steps already use the target category
+	for _, step := range steps[:len(steps)-1] {
+		scopeFlag, err := groupScopeFlag(step)
+		if err != nil {
+			return err
+		}
+
+		err = c.UpdateGroup(dn, map[string][]string{
+			"groupType": {fmt.Sprintf("%d", to&^groupTypeScopeFlags|scopeFlag)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to convert group %s to %s scope: %w", dn, step, err)
+		}
+	}
+
+	return c.UpdateGroup(dn, map[string][]string{
+		"groupType": {fmt.Sprintf("%d", to)},
+	})
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeGroupType(t *testing.T) {
+	tests := []struct {
+		scope     string
+		category  string
+		groupType int64
+	}{
+		{GroupScopeGlobal, GroupCategorySecurity, -2147483646},
+		{GroupScopeDomainLocal, GroupCategorySecurity, -2147483644},
+		{GroupScopeUniversal, GroupCategorySecurity, -2147483640},
+		{GroupScopeGlobal, GroupCategoryDistribution, 2},
+		{GroupScopeDomainLocal, GroupCategoryDistribution, 4},
+		{GroupScopeUniversal, GroupCategoryDistribution, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope+"/"+tt.category, func(t *testing.T) {
+			groupType, err := ComputeGroupType(tt.scope, tt.category)
+			if err != nil {
+				t.Fatalf("ComputeGroupType(%q, %q) returned error: %s", tt.scope, tt.category, err)
+			}
+			if groupType != tt.groupType {
+				t.Errorf("ComputeGroupType(%q, %q) = %d, want %d", tt.scope, tt.category, groupType, tt.groupType)
+			}
+
+			scope, category, builtin, err := DescribeGroupType(groupType)
+			if err != nil {
+				t.Fatalf("DescribeGroupType(%d) returned error: %s", groupType, err)
+			}
+			if scope != tt.scope || category != tt.category || builtin {
+				t.Errorf("DescribeGroupType(%d) = %q, %q, %t, want %q, %q, false", groupType, scope, category, builtin, tt.scope, tt.category)
+			}
+		})
+	}
+}
+
+func TestComputeGroupTypeInvalid(t *testing.T) {
+	for _, args := range [][2]string{
+		{"local", GroupCategorySecurity},
+		{GroupScopeGlobal, "mail"},
+		{"", ""},
+	} {
+		if _, err := ComputeGroupType(args[0], args[1]); err == nil {
+			t.Errorf("ComputeGroupType(%q, %q) returned no error", args[0], args[1])
+		}
+	}
+}
+
+func TestDescribeGroupTypeInvalid(t *testing.T) {
+	for _, groupType := range []int64{
+		0,                                    // no scope
+		GroupTypeGlobal | GroupTypeUniversal, // two scopes
+		GroupTypeGlobal | 0x10,               // unknown flag
+		GroupTypeBuiltinLocal | GroupTypeGlobal | GroupTypeSecurity, // builtin on a global group
+		GroupTypeBuiltinLocal | GroupTypeDomainLocal,                // builtin on a distribution group
+	} {
+		if _, _, _, err := DescribeGroupType(groupType); err == nil {
+			t.Errorf("DescribeGroupType(%d) returned no error", groupType)
+		}
+	}
+
+	_, _, builtin, err := DescribeGroupType(GroupTypeBuiltinLocal | GroupTypeDomainLocal | GroupTypeSecurity)
+	if err != nil || !builtin {
+		t.Errorf("DescribeGroupType of a builtin domain local security group = builtin %t, error %v", builtin, err)
+	}
+}
+
+func TestGroupScopeConversionSteps(t *testing.T) {
+	tests := []struct {
+		from  string
+		to    string
+		steps []string
+	}{
+		{GroupScopeGlobal, GroupScopeGlobal, nil},
+		{GroupScopeGlobal, GroupScopeUniversal, []string{GroupScopeUniversal}},
+		{GroupScopeGlobal, GroupScopeDomainLocal, []string{GroupScopeUniversal, GroupScopeDomainLocal}},
+		{GroupScopeDomainLocal, GroupScopeDomainLocal, nil},
+		{GroupScopeDomainLocal, GroupScopeUniversal, []string{GroupScopeUniversal}},
+		{GroupScopeDomainLocal, GroupScopeGlobal, []string{GroupScopeUniversal, GroupScopeGlobal}},
+		{GroupScopeUniversal, GroupScopeUniversal, nil},
+		{GroupScopeUniversal, GroupScopeGlobal, []string{GroupScopeGlobal}},
+		{GroupScopeUniversal, GroupScopeDomainLocal, []string{GroupScopeDomainLocal}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			steps := GroupScopeConversionSteps(tt.from, tt.to)
+			if !reflect.DeepEqual(steps, tt.steps) {
+				t.Errorf("GroupScopeConversionSteps(%q, %q) = %v, want %v", tt.from, tt.to, steps, tt.steps)
+			}
+
+			// Every single step must be a direct conversion AD allows: to
+			// or from universal
+			previous := tt.from
+			for _, step := range steps {
+				if previous != GroupScopeUniversal && step != GroupScopeUniversal {
+					t.Errorf("step %s -> %s is not a direct conversion", previous, step)
+				}
+				previous = step
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// FormatGUID converts a raw objectGUID value into its string representation
+// (e.g. "b3f0d3e6-5c2a-4d4e-9f0a-1a2b3c4d5e6f")
+func FormatGUID(raw []byte) string {
+	if len(raw) != 16 {
+		return ""
+	}
+
+	// The first three components are stored little-endian
+	return fmt.Sprintf("%08x-%04x-%04x-%x-%x",
+		binary.LittleEndian.Uint32(raw[0:4]),
+		binary.LittleEndian.Uint16(raw[4:6]),
+		binary.LittleEndian.Uint16(raw[6:8]),
+		raw[8:10],
+		raw[10:16],
+	)
+}
+
+// FormatSID converts a raw objectSid value into its string representation
+// (e.g. "S-1-5-21-1004336348-1177238915-682003330-512")
+func FormatSID(raw []byte) string {
+	if len(raw) < 8 {
+		return ""
+	}
+
+	revision := raw[0]
+	subAuthorityCount := int(raw[1])
+	if len(raw) != 8+4*subAuthorityCount {
+		return ""
+	}
+
+	// The identifier authority is a 48-bit big-endian value
+	var authority uint64
+	for _, b := range raw[2:8] {
+		authority = authority<<8 | uint64(b)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "S-%d-%d", revision, authority)
+	for i := 0; i < subAuthorityCount; i++ {
+		offset := 8 + 4*i
+		fmt.Fprintf(&sb, "-%d", binary.LittleEndian.Uint32(raw[offset:offset+4]))
+	}
+
+	return sb.String()
+}
+
+// GUIDReference returns a DN-valued reference to the object with the given
+// GUID. Active Directory accepts this form anywhere a DN is expected, so it
+// keeps working after the object is renamed or moved.
+func GUIDReference(guid string) string {
+	return fmt.Sprintf("<GUID=%s>", guid)
+}
+
+// SIDReference returns a DN-valued reference to the object with the given
+// SID, in the same form as GUIDReference
+func SIDReference(sid string) string {
+	return fmt.Sprintf("<SID=%s>", sid)
+}
+
+// ExtendedDN is a DN value returned with the extended DN control
+type ExtendedDN struct {
+	DN   string
+	GUID string
+	SID  string
+}
+
+// ParseExtendedDN parses a value of the form "<GUID=...>;<SID=...>;CN=..."
+// as returned by the extended DN control in string mode
+func ParseExtendedDN(value string) ExtendedDN {
+	var result ExtendedDN
+
+	for strings.HasPrefix(value, "<") {
+		end := strings.Index(value, ">")
+		if end < 0 {
+			break
+		}
+
+		component := value[1:end]
+		switch {
+		case strings.HasPrefix(strings.ToUpper(component), "GUID="):
+			result.GUID = strings.ToLower(component[len("GUID="):])
+		case strings.HasPrefix(strings.ToUpper(component), "SID="):
+			result.SID = component[len("SID="):]
+		}
+
+		value = strings.TrimPrefix(value[end+1:], ";")
+	}
+
+	result.DN = value
+	return result
+}
+
+// GetObjectGUID retrieves the objectGUID of any object by its distinguished name
+func (c *Client) GetObjectGUID(dn string) (string, error) {
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=*)",
+		[]string{"objectGUID"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to search for object %s: %w", dn, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return "", fmt.Errorf("object not found: %s", dn)
+	}
+
+	return FormatGUID(result.Entries[0].GetRawAttributeValue("objectGUID")), nil
+}
+
+// GetObjectSID retrieves the objectSid of any object by its distinguished name
+func (c *Client) GetObjectSID(dn string) (string, error) {
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=*)",
+		[]string{"objectSid"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to search for object %s: %w", dn, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return "", fmt.Errorf("object not found: %s", dn)
+	}
+
+	sid := FormatSID(result.Entries[0].GetRawAttributeValue("objectSid"))
+	if sid == "" {
+		return "", fmt.Errorf("object %s is %w", dn, ErrNotSecurityPrincipal)
+	}
+
+	return sid, nil
+}
+
+// GetDNBySID retrieves the distinguished name of the object with the given
+// objectSid
+func (c *Client) GetDNBySID(sid string) (string, error) {
+	searchRequest := ldap.NewSearchRequest(
+		SIDReference(sid),
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=*)",
+		[]string{"objectSid"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to search for object with SID %s: %w", sid, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return "", fmt.Errorf("object not found with SID: %s", sid)
+	}
+
+	return result.Entries[0].DN, nil
+}
+
+// GetDNByGUID retrieves the distinguished name of the object with the given
+// objectGUID
+func (c *Client) GetDNByGUID(guid string) (string, error) {
+	searchRequest := ldap.NewSearchRequest(
+		GUIDReference(guid),
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=*)",
+		[]string{"objectGUID"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to search for object with GUID %s: %w", guid, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return "", fmt.Errorf("object not found with GUID: %s", guid)
+	}
+
+	return result.Entries[0].DN, nil
+}
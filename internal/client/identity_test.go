@@ -0,0 +1,113 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFormatGUID(t *testing.T) {
+	// The member attribute's schemaIDGUID, as stored in the directory
+	raw := []byte{0xc0, 0x79, 0x96, 0xbf, 0xe6, 0x0d, 0xd0, 0x11, 0xa2, 0x85, 0x00, 0xaa, 0x00, 0x30, 0x49, 0xe2}
+	const guid = "bf9679c0-0de6-11d0-a285-00aa003049e2"
+
+	if formatted := FormatGUID(raw); formatted != guid {
+		t.Errorf("FormatGUID = %s, want %s", formatted, guid)
+	}
+
+	for _, value := range []string{guid, "BF9679C0-0DE6-11D0-A285-00AA003049E2", "{" + guid + "}"} {
+		parsed, err := ParseGUID(value)
+		if err != nil {
+			t.Fatalf("ParseGUID(%q) returned error: %s", value, err)
+		}
+		if !bytes.Equal(parsed, raw) {
+			t.Errorf("ParseGUID(%q) = %x, want %x", value, parsed, raw)
+		}
+	}
+
+	for _, invalid := range [][]byte{nil, raw[:15], append(raw, 0)} {
+		if formatted := FormatGUID(invalid); formatted != "" {
+			t.Errorf("FormatGUID(%x) = %q, want \"\"", invalid, formatted)
+		}
+	}
+}
+
+func TestEscapeFilterBytes(t *testing.T) {
+	raw, err := ParseGUID("bf9679c0-0de6-11d0-a285-00aa003049e2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `\c0\79\96\bf\e6\0d\d0\11\a2\85\00\aa\00\30\49\e2`
+	if escaped := escapeFilterBytes(raw); escaped != want {
+		t.Errorf("escapeFilterBytes = %s, want %s", escaped, want)
+	}
+}
+
+func TestFormatSID(t *testing.T) {
+	tests := []string{
+		SIDEveryone,
+		SIDBuiltinAdministrators,
+		"S-1-5-21-1004336348-1177238915-682003330-512",
+	}
+
+	for _, sid := range tests {
+		raw, err := ParseSID(sid)
+		if err != nil {
+			t.Fatalf("ParseSID(%q) returned error: %s", sid, err)
+		}
+		if formatted := FormatSID(raw); formatted != sid {
+			t.Errorf("FormatSID(ParseSID(%q)) = %q", sid, formatted)
+		}
+	}
+
+	// The sub-authority count must match the length
+	if formatted := FormatSID([]byte{1, 2, 0, 0, 0, 0, 0, 5, 32, 0, 0, 0}); formatted != "" {
+		t.Errorf("FormatSID of a truncated SID = %q, want \"\"", formatted)
+	}
+}
+
+func TestParseExtendedDN(t *testing.T) {
+	tests := []struct {
+		value string
+		want  ExtendedDN
+	}{
+		{
+			value: "<GUID=bf9679c0-0de6-11d0-a285-00aa003049e2>;<SID=S-1-5-21-1004336348-1177238915-682003330-1105>;CN=Jane Doe,OU=Users,DC=example,DC=com",
+			want: ExtendedDN{
+				DN:   "CN=Jane Doe,OU=Users,DC=example,DC=com",
+				GUID: "bf9679c0-0de6-11d0-a285-00aa003049e2",
+				SID:  "S-1-5-21-1004336348-1177238915-682003330-1105",
+			},
+		},
+		{
+			// Objects without a SID, such as contacts, only carry a GUID
+			value: "<GUID=BF9679C0-0DE6-11D0-A285-00AA003049E2>;CN=Partner,OU=Contacts,DC=example,DC=com",
+			want: ExtendedDN{
+				DN:   "CN=Partner,OU=Contacts,DC=example,DC=com",
+				GUID: "bf9679c0-0de6-11d0-a285-00aa003049e2",
+			},
+		},
+		{
+			value: "CN=Plain,DC=example,DC=com",
+			want:  ExtendedDN{DN: "CN=Plain,DC=example,DC=com"},
+		},
+		{
+			// Escaped angle brackets in the DN itself are left alone
+			value: "<GUID=bf9679c0-0de6-11d0-a285-00aa003049e2>;CN=a\\<b\\>,DC=example,DC=com",
+			want: ExtendedDN{
+				DN:   "CN=a\\<b\\>,DC=example,DC=com",
+				GUID: "bf9679c0-0de6-11d0-a285-00aa003049e2",
+			},
+		},
+		{
+			value: "<GUID=unterminated",
+			want:  ExtendedDN{DN: "<GUID=unterminated"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := ParseExtendedDN(tt.value); got != tt.want {
+			t.Errorf("ParseExtendedDN(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Global catalog ports, used for forest-wide searches
+const (
+	globalCatalogPort    = 3268
+	globalCatalogTLSPort = 3269
+)
+
+// ErrGlobalCatalogUnavailable means the global catalog could not be reached,
+// e.g. because the server is not a global catalog
+var ErrGlobalCatalogUnavailable = errors.New("global catalog unavailable")
+
+// Proxy address prefixes. The upper-case SMTP prefix marks the primary
+// address; secondary SMTP addresses use the lower-case prefix.
+const (
+	ProxyAddressPrimarySMTP   = "SMTP:"
+	ProxyAddressSecondarySMTP = "smtp:"
+)
+
+// searchGlobalCatalog performs a paged search against the configured global
+// catalog, or the configured server if there is none. A separate connection
+// is used since the global catalog listens on its own port.
+func (c *Client) searchGlobalCatalog(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	server := c.globalCatalog
+	if server == "" {
+		server = c.server
+	}
+
+	var conn *ldap.Conn
+	var err error
+
+	if c.useTLS {
+		address := fmt.Sprintf("%s:%d", server, globalCatalogTLSPort)
+		conn, err = ldap.DialTLS("tcp", address, &tls.Config{InsecureSkipVerify: c.insecure, ServerName: server})
+	} else {
+		address := fmt.Sprintf("%s:%d", server, globalCatalogPort)
+		conn, err = ldap.Dial("tcp", address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to dial %s: %s", ErrGlobalCatalogUnavailable, server, err)
+	}
+	defer conn.Close()
+
+	if err := conn.Bind(c.username, c.password); err != nil {
+		return nil, fmt.Errorf("failed to bind to global catalog: %w", err)
+	}
+
+	result, err := conn.SearchWithPaging(searchRequest, searchPageSize)
+	if err != nil {
+		return nil, fmt.Errorf("global catalog search failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// FindMailConflicts searches the forest for objects other than the one with
+// objectGUID excludeGUID that already use any of the given SMTP addresses,
+// either as mail or as a proxy address. The result maps each address in use
+// to the DNs of the objects using it. If the global catalog can't be
+// reached, only the domain is searched and forestWide is false.
+func (c *Client) FindMailConflicts(addresses []string, excludeGUID string) (conflicts map[string][]string, forestWide bool, err error) {
+	conflicts = make(map[string][]string)
+	if len(addresses) == 0 {
+		return conflicts, true, nil
+	}
+
+	// Proxy addresses match case-insensitively, so the secondary prefix
+	// also finds primary addresses
+	var filter strings.Builder
+	filter.WriteString("(|")
+	for _, address := range addresses {
+		escaped := EscapeFilter(address)
+		fmt.Fprintf(&filter, "(mail=%s)(proxyAddresses=%s%s)", escaped, ProxyAddressSecondarySMTP, escaped)
+	}
+	filter.WriteString(")")
+
+	// An empty base searches every domain in the global catalog
+	searchRequest := ldap.NewSearchRequest(
+		"",
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter.String(),
+		[]string{"mail", "proxyAddresses", "objectGUID"},
+		nil,
+	)
+
+	forestWide = true
+	result, err := c.searchGlobalCatalog(searchRequest)
+	if errors.Is(err, ErrGlobalCatalogUnavailable) {
+		domainDN, domainErr := c.DefaultNamingContext()
+		if domainErr != nil {
+			return nil, false, domainErr
+		}
+
+		searchRequest.BaseDN = domainDN
+		forestWide = false
+		result, err = c.SearchPaged(searchRequest)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to check mail addresses for conflicts: %w", err)
+	}
+
+	for _, entry := range result.Entries {
+		if excludeGUID != "" && strings.EqualFold(FormatGUID(entry.GetRawAttributeValue("objectGUID")), excludeGUID) {
+			continue
+		}
+
+		for _, address := range addresses {
+			if entryUsesAddress(entry, address) {
+				conflicts[address] = append(conflicts[address], entry.DN)
+			}
+		}
+	}
+
+	return conflicts, forestWide, nil
+}
+
+// entryUsesAddress reports whether the entry has the SMTP address as its
+// mail or as one of its proxy addresses
+func entryUsesAddress(entry *ldap.Entry, address string) bool {
+	if strings.EqualFold(entry.GetAttributeValue("mail"), address) {
+		return true
+	}
+
+	for _, proxy := range entry.GetAttributeValues("proxyAddresses") {
+		if strings.EqualFold(proxy, ProxyAddressSecondarySMTP+address) {
+			return true
+		}
+	}
+
+	return false
+}
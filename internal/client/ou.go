@@ -0,0 +1,156 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// OrganizationalUnit represents an Active Directory organizational unit
+type OrganizationalUnit struct {
+	DN          string `json:"dn"`
+	Name        string `json:"name"`
+	ParentDN    string `json:"parent_dn"`
+	Description string `json:"description"`
+	ObjectGUID  string `json:"object_guid"`
+}
+
+// organizationalUnitAttributes lists the attributes fetched for every OU
+// lookup
+var organizationalUnitAttributes = []string{
+	"ou",
+	"description",
+	"objectGUID",
+}
+
+// organizationalUnitFromEntry maps a search result entry to an
+// OrganizationalUnit
+func organizationalUnitFromEntry(entry *ldap.Entry) *OrganizationalUnit {
+	parent, _ := ParentDN(entry.DN)
+
+	return &OrganizationalUnit{
+		DN:          entry.DN,
+		Name:        entry.GetAttributeValue("ou"),
+		ParentDN:    parent,
+		Description: entry.GetAttributeValue("description"),
+		ObjectGUID:  FormatGUID(entry.GetRawAttributeValue("objectGUID")),
+	}
+}
+
+// GetOrganizationalUnit retrieves an OU by its distinguished name
+func (c *Client) GetOrganizationalUnit(dn string) (*OrganizationalUnit, error) {
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=organizationalUnit)",
+		organizationalUnitAttributes,
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for organizational unit %s: %w", dn, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("organizational unit not found: %s", dn)
+	}
+
+	return organizationalUnitFromEntry(result.Entries[0]), nil
+}
+
+// GetOrganizationalUnitByGUID retrieves an OU by its objectGUID
+func (c *Client) GetOrganizationalUnitByGUID(guid string) (*OrganizationalUnit, error) {
+	ou, err := c.GetOrganizationalUnit(GUIDReference(guid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organizational unit with GUID %s: %w", guid, err)
+	}
+
+	return ou, nil
+}
+
+// CreateOrganizationalUnit creates a new OU below parentDN in a single add
+// operation and returns its DN
+func (c *Client) CreateOrganizationalUnit(parentDN, name string, attributes map[string][]string) (string, error) {
+	dn := fmt.Sprintf("OU=%s,%s", EscapeDN(name), parentDN)
+
+	required := map[string][]string{
+		"ou": {name},
+	}
+
+	err := c.addEntry(dn, []string{"top", "organizationalUnit"}, entryAttributes(attributes, required))
+	if err != nil {
+		return "", fmt.Errorf("failed to create organizational unit %s: %w", dn, err)
+	}
+
+	return dn, nil
+}
+
+// UpdateOrganizationalUnit updates an existing OU
+func (c *Client) UpdateOrganizationalUnit(dn string, updates map[string][]string) error {
+	err := c.modifyAttributes(dn, updates)
+	if err != nil {
+		return fmt.Errorf("failed to update organizational unit %s: %w", dn, err)
+	}
+
+	return nil
+}
+
+// RenameOrganizationalUnit renames and/or moves an OU and returns its new
+// DN. The objectGUID is preserved.
+func (c *Client) RenameOrganizationalUnit(dn, name, parentDN string) (string, error) {
+	return c.RenameObject(dn, "OU="+EscapeDN(name), parentDN)
+}
+
+// DeleteOrganizationalUnit deletes an OU. With recursive set, the tree
+// delete control is used to remove everything below it as well.
+func (c *Client) DeleteOrganizationalUnit(dn string, recursive bool) error {
+	var controls []ldap.Control
+	if recursive {
+		controls = append(controls, ldap.NewControlSubtreeDelete())
+	}
+
+	err := c.Delete(ldap.NewDelRequest(dn, controls))
+	if err != nil {
+		return fmt.Errorf("failed to delete organizational unit %s: %w", dn, err)
+	}
+
+	return nil
+}
+
+// ListOrganizationalUnits lists the OUs below searchBase that match the
+// given filter. The filter is combined with the OU object filter; an empty
+// searchBase defaults to the configured base DN.
+func (c *Client) ListOrganizationalUnits(searchBase string, scope int, filter string) ([]*OrganizationalUnit, error) {
+	if searchBase == "" {
+		searchBase = c.baseDN
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		searchBase,
+		scope,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&(objectClass=organizationalUnit)%s)", filter),
+		organizationalUnitAttributes,
+		nil,
+	)
+
+	result, err := c.SearchPaged(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizational units: %w", err)
+	}
+
+	ous := make([]*OrganizationalUnit, len(result.Entries))
+	for i, entry := range result.Entries {
+		ous[i] = organizationalUnitFromEntry(entry)
+	}
+
+	return ous, nil
+}
@@ -0,0 +1,281 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// gidNumberAllocationAttempts bounds the number of lost compare-and-swap
+// races while allocating a gidNumber
+const gidNumberAllocationAttempts = 10
+
+// gidNumberCounterAttribute holds the next gidNumber to allocate on the
+// counter object, as maintained by Identity Management for UNIX
+const gidNumberCounterAttribute = "msSFU30MaxGidNumber"
+
+// GIDNumberRange returns the configured gidNumber allocation range, and
+// whether one is configured
+func (c *Client) GIDNumberRange() (min, max int64, ok bool) {
+	return c.gidNumberMin, c.gidNumberMax, c.gidNumberMin > 0 && c.gidNumberMax >= c.gidNumberMin
+}
+
+// AllocateGIDNumber returns the next free gidNumber in the configured
+// range: one above the highest gidNumber used by a group, found with a paged
+// search. If a gidNumber counter (msSFU30MaxGidNumber) is configured or
+// found, the number is also reserved on it before it is written, with a
+// compare-and-add: a single modify deleting the value that was read and
+// adding the next one. The modify fails when another allocation advanced the
+// counter first, in which case the allocation is retried. Without a counter
+// the number isn't reserved, so concurrent allocations can pick the same one.
+func (c *Client) AllocateGIDNumber() (int64, error) {
+	min, max, ok := c.GIDNumberRange()
+	if !ok {
+		return 0, fmt.Errorf("no gidNumber allocation range is configured")
+	}
+
+	used, err := c.usedGIDNumbers(min, max)
+	if err != nil {
+		return 0, err
+	}
+	highest := highestGIDNumber(used, min)
+
+	counterDN, err := c.gidNumberCounterDN()
+	if err != nil {
+		return 0, err
+	}
+	if counterDN == "" {
+		return nextGIDNumber(highest+1, min, max, used)
+	}
+
+	for attempt := 0; attempt < gidNumberAllocationAttempts; attempt++ {
+		attributes, err := c.GetAttributes(counterDN, []string{gidNumberCounterAttribute})
+		if err != nil {
+			return 0, fmt.Errorf("failed to read the gidNumber counter: %w", err)
+		}
+
+		var counter string
+		if values := attributes[gidNumberCounterAttribute]; len(values) > 0 {
+			counter = values[0]
+		}
+
+		// The counter may lag behind numbers set without it
+		start := highest + 1
+		if counter != "" {
+			next, err := strconv.ParseInt(counter, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("the gidNumber counter %s has an invalid value %q: %w", counterDN, counter, err)
+			}
+			if next > start {
+				start = next
+			}
+		}
+
+		gid, err := nextGIDNumber(start, min, max, used)
+		if err != nil {
+			return 0, err
+		}
+
+		modifyRequest := ldap.NewModifyRequest(counterDN, nil)
+		if counter != "" {
+			modifyRequest.Delete(gidNumberCounterAttribute, []string{counter})
+		}
+		modifyRequest.Add(gidNumberCounterAttribute, []string{strconv.FormatInt(gid+1, 10)})
+
+		err = c.Modify(modifyRequest)
+		if isCompareAndSwapConflict(err) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to advance the gidNumber counter %s: %w", counterDN, err)
+		}
+
+		return gid, nil
+	}
+
+	return 0, fmt.Errorf("failed to allocate a gidNumber from the counter %s after %d attempts", counterDN, gidNumberAllocationAttempts)
+}
+
+// highestGIDNumber returns the highest used gidNumber, or one below the
+// range if none is used
+func highestGIDNumber(used map[int64]bool, min int64) int64 {
+	highest := min - 1
+	for gid := range used {
+		if gid > highest {
+			highest = gid
+		}
+	}
+	return highest
+}
+
+// nextGIDNumber returns the first number from start on that is within the
+// range and not used
+func nextGIDNumber(start, min, max int64, used map[int64]bool) (int64, error) {
+	if start < min {
+		start = min
+	}
+
+	for gid := start; gid <= max; gid++ {
+		if !used[gid] {
+			return gid, nil
+		}
+	}
+
+	return 0, fmt.Errorf("the gidNumber range %d-%d is exhausted", min, max)
+}
+
+// isCompareAndSwapConflict reports whether a counter update failed because
+// the counter no longer has the value that was read: it was changed
+// (noSuchAttribute), or was set by someone else while it had no value
+// (attributeOrValueExists)
+func isCompareAndSwapConflict(err error) bool {
+	var ldapErr *ldap.Error
+	return errors.As(err, &ldapErr) &&
+		(ldapErr.ResultCode == ldap.LDAPResultNoSuchAttribute || ldapErr.ResultCode == ldap.LDAPResultAttributeOrValueExists)
+}
+
+// gidNumberCounterDN returns the object holding the gidNumber counter: the
+// configured one, or the NIS domain object of Identity Management for UNIX.
+// It returns "" if there is no such object.
+func (c *Client) gidNumberCounterDN() (string, error) {
+	if c.gidNumberCounter != "" {
+		return c.gidNumberCounter, nil
+	}
+
+	// The System container is in the domain, not below the base DN
+	domainDN, err := c.DefaultNamingContext()
+	if err != nil {
+		return "", err
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		"CN=ypservers,CN=ypServ30,CN=RpcServices,CN=System,"+domainDN,
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=msSFU30DomainInfo)",
+		[]string{"distinguishedName"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		if IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to search for the gidNumber counter: %w", err)
+	}
+
+	// With several NIS domains it isn't clear which counter to use
+	if len(result.Entries) > 1 {
+		return "", fmt.Errorf("%d NIS domain objects (msSFU30DomainInfo) hold a gidNumber counter, set gid_number_counter_dn to the one to use", len(result.Entries))
+	}
+	if len(result.Entries) == 0 {
+		return "", nil
+	}
+
+	return result.Entries[0].DN, nil
+}
+
+// usedGIDNumbers returns the gidNumbers in the range used by groups in the
+// domain
+func (c *Client) usedGIDNumbers(min, max int64) (map[int64]bool, error) {
+	// gidNumbers are unique in the domain, not just below the base DN
+	domainDN, err := c.DefaultNamingContext()
+	if err != nil {
+		return nil, err
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		domainDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&(objectClass=group)(gidNumber>=%d)(gidNumber<=%d))", min, max),
+		[]string{"gidNumber"},
+		nil,
+	)
+
+	result, err := c.SearchPaged(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for used gidNumbers: %w", err)
+	}
+
+	used := make(map[int64]bool, len(result.Entries))
+	for _, entry := range result.Entries {
+		gid, err := strconv.ParseInt(entry.GetAttributeValue("gidNumber"), 10, 64)
+		if err == nil {
+			used[gid] = true
+		}
+	}
+
+	return used, nil
+}
+
+// FindGroupsByGIDNumber returns the DNs of the groups in the domain using a
+// gidNumber
+func (c *Client) FindGroupsByGIDNumber(gid int64) ([]string, error) {
+	domainDN, err := c.DefaultNamingContext()
+	if err != nil {
+		return nil, err
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		domainDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&(objectClass=group)(gidNumber=%d))", gid),
+		[]string{"distinguishedName"},
+		nil,
+	)
+
+	result, err := c.SearchPaged(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for groups with gidNumber %d: %w", gid, err)
+	}
+
+	return entryDNs(result.Entries), nil
+}
+
+// GetPosixMemberUIDs returns the memberUid values matching the direct user
+// members of a group: their uid, or their sAMAccountName if uid is not set
+func (c *Client) GetPosixMemberUIDs(groupDN string) ([]string, error) {
+	searchRequest := ldap.NewSearchRequest(
+		c.baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&%s(memberOf=%s))", userFilter, EscapeFilter(groupDN)),
+		[]string{"uid", "sAMAccountName"},
+		nil,
+	)
+
+	result, err := c.SearchPaged(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for members of group %s: %w", groupDN, err)
+	}
+
+	uids := make([]string, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		uid := entry.GetAttributeValue("uid")
+		if uid == "" {
+			uid = entry.GetAttributeValue("sAMAccountName")
+		}
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+
+	return uids, nil
+}
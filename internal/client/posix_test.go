@@ -0,0 +1,95 @@
+package client
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+func TestNextGIDNumber(t *testing.T) {
+	tests := []struct {
+		name  string
+		start int64
+		used  []int64
+		gid   int64
+		err   bool
+	}{
+		{name: "counter at min", start: 10000, gid: 10000},
+		{name: "counter below range", start: 5, gid: 10000},
+		{name: "counter inside range", start: 10005, gid: 10005},
+		{name: "skips used numbers", start: 10005, used: []int64{10005, 10006}, gid: 10007},
+		{name: "used numbers below counter", start: 10005, used: []int64{10000, 10004}, gid: 10005},
+		{name: "last number", start: 10009, gid: 10009},
+		{name: "counter past max", start: 10010, err: true},
+		{name: "rest of range used", start: 10008, used: []int64{10008, 10009}, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			used := make(map[int64]bool)
+			for _, gid := range tt.used {
+				used[gid] = true
+			}
+
+			gid, err := nextGIDNumber(tt.start, 10000, 10009, used)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("nextGIDNumber(%d) = %d, want error", tt.start, gid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("nextGIDNumber(%d) returned error: %s", tt.start, err)
+			}
+			if gid != tt.gid {
+				t.Errorf("nextGIDNumber(%d) = %d, want %d", tt.start, gid, tt.gid)
+			}
+		})
+	}
+}
+
+func TestHighestGIDNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		used []int64
+		want int64
+	}{
+		{"none used", nil, 9999},
+		{"one used", []int64{10003}, 10003},
+		{"gaps", []int64{10000, 10007, 10002}, 10007},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			used := make(map[int64]bool)
+			for _, gid := range tt.used {
+				used[gid] = true
+			}
+
+			if got := highestGIDNumber(used, 10000); got != tt.want {
+				t.Errorf("highestGIDNumber(%v) = %d, want %d", tt.used, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCompareAndSwapConflict(t *testing.T) {
+	tests := []struct {
+		err      error
+		conflict bool
+	}{
+		{nil, false},
+		{ldap.NewError(ldap.LDAPResultNoSuchAttribute, fmt.Errorf("value changed")), true},
+		{ldap.NewError(ldap.LDAPResultAttributeOrValueExists, fmt.Errorf("value set")), true},
+		{fmt.Errorf("modify: %w", ldap.NewError(ldap.LDAPResultNoSuchAttribute, fmt.Errorf("value changed"))), true},
+		{ldap.NewError(ldap.LDAPResultInsufficientAccessRights, fmt.Errorf("denied")), false},
+		{ldap.NewError(ldap.LDAPResultNoSuchObject, fmt.Errorf("gone")), false},
+	}
+
+	for _, tt := range tests {
+		if conflict := isCompareAndSwapConflict(tt.err); conflict != tt.conflict {
+			t.Errorf("isCompareAndSwapConflict(%v) = %t, want %t", tt.err, conflict, tt.conflict)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// privilegedDomainRIDs maps the RIDs of the domain groups protected by
+// AdminSDHolder to their names
+var privilegedDomainRIDs = map[uint64]string{
+	512: "Domain Admins",
+	516: "Domain Controllers",
+	518: "Schema Admins",
+	519: "Enterprise Admins",
+	521: "Read-only Domain Controllers",
+	526: "Key Admins",
+	527: "Enterprise Key Admins",
+}
+
+// privilegedBuiltinRIDs maps the RIDs of the protected groups in the Builtin
+// domain (S-1-5-32) to their names
+var privilegedBuiltinRIDs = map[uint64]string{
+	544: "Administrators",
+	548: "Account Operators",
+	549: "Server Operators",
+	550: "Print Operators",
+	551: "Backup Operators",
+	552: "Replicator",
+}
+
+// PrivilegedWritesEnabled reports whether the provider allows writes to
+// privileged groups for resources that opt in
+func (c *Client) PrivilegedWritesEnabled() bool {
+	return c.privilegedWritesEnabled
+}
+
+// PrivilegedGroupReason explains why a group is privileged: it is marked
+// with adminCount=1, is one of the well-known groups protected by
+// AdminSDHolder, or is listed in the configured privileged groups. It
+// returns "" for other groups.
+func (c *Client) PrivilegedGroupReason(dn string) (string, error) {
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=group)",
+		[]string{"sAMAccountName", "objectSid", "adminCount"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to search for group %s: %w", dn, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return "", fmt.Errorf("group not found: %s", dn)
+	}
+
+	entry := result.Entries[0]
+	sam := entry.GetAttributeValue("sAMAccountName")
+
+	for _, group := range c.privilegedGroups {
+		if DNEqual(group, entry.DN) || strings.EqualFold(group, sam) {
+			return "it is listed in the provider's privileged_groups", nil
+		}
+	}
+
+	if name, ok := privilegedGroupName(FormatSID(entry.GetRawAttributeValue("objectSid"))); ok {
+		return fmt.Sprintf("it is the well-known %s group", name), nil
+	}
+
+	if entry.GetAttributeValue("adminCount") == "1" {
+		return "it has adminCount=1, so it is protected by AdminSDHolder", nil
+	}
+
+	return "", nil
+}
+
+// privilegedGroupName returns the name of the well-known privileged group
+// with the given SID
+func privilegedGroupName(sid string) (string, bool) {
+	i := strings.LastIndex(sid, "-")
+	if i < 0 {
+		return "", false
+	}
+
+	rid, err := strconv.ParseUint(sid[i+1:], 10, 32)
+	if err != nil {
+		return "", false
+	}
+
+	var name string
+	var ok bool
+	switch {
+	case sid[:i] == "S-1-5-32":
+		name, ok = privilegedBuiltinRIDs[rid]
+	case strings.HasPrefix(sid, "S-1-5-21-"):
+		name, ok = privilegedDomainRIDs[rid]
+	}
+
+	return name, ok
+}
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// passwordSettingsFilter matches password settings objects
+const passwordSettingsFilter = "(objectClass=msDS-PasswordSettings)"
+
+// PasswordSettings represents a fine-grained password policy
+// (msDS-PasswordSettings object). A zero MaxPasswordAge means passwords
+// never expire; a zero LockoutDuration means locked out accounts stay
+// locked until an administrator unlocks them.
+type PasswordSettings struct {
+	DN                          string        `json:"dn"`
+	Name                        string        `json:"name"`
+	Description                 string        `json:"description"`
+	Precedence                  int           `json:"precedence"`
+	MinPasswordLength           int           `json:"min_password_length"`
+	PasswordHistoryLength       int           `json:"password_history_length"`
+	ComplexityEnabled           bool          `json:"complexity_enabled"`
+	ReversibleEncryptionEnabled bool          `json:"reversible_encryption_enabled"`
+	MinPasswordAge              time.Duration `json:"min_password_age"`
+	MaxPasswordAge              time.Duration `json:"max_password_age"`
+	LockoutThreshold            int           `json:"lockout_threshold"`
+	LockoutObservationWindow    time.Duration `json:"lockout_observation_window"`
+	LockoutDuration             time.Duration `json:"lockout_duration"`
+	AppliesTo                   []string      `json:"applies_to"`
+	ObjectGUID                  string        `json:"object_guid"`
+}
+
+// passwordSettingsAttributes lists the attributes fetched for every PSO
+// lookup
+var passwordSettingsAttributes = []string{
+	"cn",
+	"description",
+	"msDS-PasswordSettingsPrecedence",
+	"msDS-MinimumPasswordLength",
+	"msDS-PasswordHistoryLength",
+	"msDS-PasswordComplexityEnabled",
+	"msDS-PasswordReversibleEncryptionEnabled",
+	"msDS-MinimumPasswordAge",
+	"msDS-MaximumPasswordAge",
+	"msDS-LockoutThreshold",
+	"msDS-LockoutObservationWindow",
+	"msDS-LockoutDuration",
+	"msDS-PSOAppliesTo",
+	"objectGUID",
+}
+
+// passwordSettingsFromEntry maps a search result entry to PasswordSettings
+func passwordSettingsFromEntry(entry *ldap.Entry) *PasswordSettings {
+	atoi := func(name string) int {
+		value, _ := strconv.Atoi(entry.GetAttributeValue(name))
+		return value
+	}
+	return &PasswordSettings{
+		DN:                          entry.DN,
+		Name:                        entry.GetAttributeValue("cn"),
+		Description:                 entry.GetAttributeValue("description"),
+		Precedence:                  atoi("msDS-PasswordSettingsPrecedence"),
+		MinPasswordLength:           atoi("msDS-MinimumPasswordLength"),
+		PasswordHistoryLength:       atoi("msDS-PasswordHistoryLength"),
+		ComplexityEnabled:           ParseBoolean(entry.GetAttributeValue("msDS-PasswordComplexityEnabled")),
+		ReversibleEncryptionEnabled: ParseBoolean(entry.GetAttributeValue("msDS-PasswordReversibleEncryptionEnabled")),
+		MinPasswordAge:              ParseInterval(entry.GetAttributeValue("msDS-MinimumPasswordAge")),
+		MaxPasswordAge:              ParseInterval(entry.GetAttributeValue("msDS-MaximumPasswordAge")),
+		LockoutThreshold:            atoi("msDS-LockoutThreshold"),
+		LockoutObservationWindow:    ParseInterval(entry.GetAttributeValue("msDS-LockoutObservationWindow")),
+		LockoutDuration:             ParseInterval(entry.GetAttributeValue("msDS-LockoutDuration")),
+		AppliesTo:                   entry.GetAttributeValues("msDS-PSOAppliesTo"),
+		ObjectGUID:                  FormatGUID(entry.GetRawAttributeValue("objectGUID")),
+	}
+}
+
+// Attributes returns the policy settings as LDAP attributes, for creating
+// or updating a PSO. The name, description and targets are not included.
+func (p *PasswordSettings) Attributes() map[string][]string {
+	itoa := func(value int) []string {
+		return []string{strconv.Itoa(value)}
+	}
+
+	return map[string][]string{
+		"msDS-PasswordSettingsPrecedence":          itoa(p.Precedence),
+		"msDS-MinimumPasswordLength":               itoa(p.MinPasswordLength),
+		"msDS-PasswordHistoryLength":               itoa(p.PasswordHistoryLength),
+		"msDS-PasswordComplexityEnabled":           {FormatBoolean(p.ComplexityEnabled)},
+		"msDS-PasswordReversibleEncryptionEnabled": {FormatBoolean(p.ReversibleEncryptionEnabled)},
+		"msDS-MinimumPasswordAge":                  {FormatInterval(p.MinPasswordAge, false)},
+		"msDS-MaximumPasswordAge":                  {FormatInterval(p.MaxPasswordAge, true)},
+		"msDS-LockoutThreshold":                    itoa(p.LockoutThreshold),
+		"msDS-LockoutObservationWindow":            {FormatInterval(p.LockoutObservationWindow, false)},
+		"msDS-LockoutDuration":                     {FormatInterval(p.LockoutDuration, true)},
+	}
+}
+
+// PasswordSettingsContainer returns the DN of the Password Settings
+// Container of the domain
+func (c *Client) PasswordSettingsContainer() (string, error) {
+	domainDN, err := c.DefaultNamingContext()
+	if err != nil {
+		return "", err
+	}
+
+	return "CN=Password Settings Container,CN=System," + domainDN, nil
+}
+
+// GetPasswordSettings retrieves a PSO by its distinguished name
+func (c *Client) GetPasswordSettings(dn string) (*PasswordSettings, error) {
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		passwordSettingsFilter,
+		passwordSettingsAttributes,
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for password settings object %s: %w", dn, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("password settings object not found: %s", dn)
+	}
+
+	return passwordSettingsFromEntry(result.Entries[0]), nil
+}
+
+// GetPasswordSettingsByGUID retrieves a PSO by its objectGUID
+func (c *Client) GetPasswordSettingsByGUID(guid string) (*PasswordSettings, error) {
+	pso, err := c.GetPasswordSettings(GUIDReference(guid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find password settings object with GUID %s: %w", guid, err)
+	}
+
+	return pso, nil
+}
+
+// CreatePasswordSettings creates a new PSO in the Password Settings
+// Container in a single add operation and returns its DN
+func (c *Client) CreatePasswordSettings(settings *PasswordSettings) (string, error) {
+	container, err := c.PasswordSettingsContainer()
+	if err != nil {
+		return "", err
+	}
+
+	dn := fmt.Sprintf("CN=%s,%s", EscapeDN(settings.Name), container)
+
+	required := map[string][]string{
+		"cn":                {settings.Name},
+		"msDS-PSOAppliesTo": settings.AppliesTo,
+	}
+	if settings.Description != "" {
+		required["description"] = []string{settings.Description}
+	}
+
+	err = c.addEntry(dn, []string{"msDS-PasswordSettings"}, entryAttributes(settings.Attributes(), required))
+	if err != nil {
+		return "", fmt.Errorf("failed to create password settings object %s: %w", dn, err)
+	}
+
+	return dn, nil
+}
+
+// UpdatePasswordSettings updates an existing PSO
+func (c *Client) UpdatePasswordSettings(dn string, updates map[string][]string) error {
+	err := c.modifyAttributes(dn, updates)
+	if err != nil {
+		return fmt.Errorf("failed to update password settings object %s: %w", dn, err)
+	}
+
+	return nil
+}
+
+// DeletePasswordSettings deletes a PSO
+func (c *Client) DeletePasswordSettings(dn string) error {
+	err := c.Delete(ldap.NewDelRequest(dn, nil))
+	if err != nil {
+		return fmt.Errorf("failed to delete password settings object %s: %w", dn, err)
+	}
+
+	return nil
+}
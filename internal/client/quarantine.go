@@ -0,0 +1,228 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Deletion modes for groups
+const (
+	DeletionModeDelete     = "delete"
+	DeletionModeQuarantine = "quarantine"
+)
+
+// quarantineMarker starts the first line of the adminDescription of
+// quarantined groups, followed by the quarantine time in RFC 3339, a space
+// and the group's original sAMAccountName
+const quarantineMarker = "terraform-quarantined:"
+
+// quarantineSAMPrefix starts the sAMAccountName of quarantined groups, which
+// is made unique with the objectGUID so the original name can be reused
+const quarantineSAMPrefix = "quarantined-"
+
+// quarantineInfoPrefix starts the line QuarantineGroup adds to info
+const quarantineInfoPrefix = "Quarantined by Terraform"
+
+// maxCNLength is the rangeUpper of cn in the schema
+const maxCNLength = 64
+
+// QuarantinedGroup is a group that was moved to the quarantine OU instead of
+// being deleted
+type QuarantinedGroup struct {
+	DN             string `json:"dn"`
+	SamAccountName string `json:"sam_account_name"`
+	// OriginalSamAccountName is the sAMAccountName before quarantine
+	OriginalSamAccountName string    `json:"original_sam_account_name"`
+	Info                   string    `json:"info"`
+	QuarantinedAt          time.Time `json:"quarantined_at"`
+	ObjectGUID             string    `json:"object_guid"`
+	ObjectSid              string    `json:"object_sid"`
+}
+
+// DeletionMode returns how destroyed groups are removed: deleted, or moved
+// to the quarantine OU
+func (c *Client) DeletionMode() string {
+	if c.deletionMode == "" {
+		return DeletionModeDelete
+	}
+	return c.deletionMode
+}
+
+// QuarantineOU returns the DN of the OU quarantined groups are moved to
+func (c *Client) QuarantineOU() string {
+	return c.quarantineOU
+}
+
+// QuarantineGroup soft-deletes a group: it is moved to the quarantine OU
+// under an RDN made unique with its objectGUID, its sAMAccountName is
+// likewise made unique so the name can be reused, its members are removed,
+// and the quarantine time, its origin and its original names are stamped
+// into adminDescription and info ahead of their previous values. The group keeps its SID, so it can be
+// moved back with its permissions intact. It returns the new DN.
+func (c *Client) QuarantineGroup(dn, origin string) (string, error) {
+	if c.quarantineOU == "" {
+		return "", fmt.Errorf("no quarantine OU is configured")
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=group)",
+		[]string{"cn", "sAMAccountName", "objectGUID", "adminDescription", "info"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to search for group %s: %w", dn, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return "", fmt.Errorf("group not found: %s", dn)
+	}
+
+	entry := result.Entries[0]
+	guid := FormatGUID(entry.GetRawAttributeValue("objectGUID"))
+
+	rdn, parent, err := SplitDN(entry.DN)
+	if err != nil {
+		return "", err
+	}
+
+	// Move first, so a group of the same name already in quarantine can't
+	// leave this one emptied and stamped in its original place
+	quarantinedDN, err := c.RenameObject(entry.DN, "CN="+EscapeDN(quarantineCN(entry.GetAttributeValue("cn"), guid)), c.quarantineOU)
+	if err != nil {
+		return "", fmt.Errorf("failed to quarantine group %s: %w", dn, err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	sam := entry.GetAttributeValue("sAMAccountName")
+
+	modifyRequest := ldap.NewModifyRequest(quarantinedDN, nil)
+	modifyRequest.Replace("member", []string{})
+	modifyRequest.Replace("sAMAccountName", []string{quarantineSAMAccountName(guid)})
+	modifyRequest.Replace("adminDescription", []string{quarantineStamp(quarantineMarker+now+" "+sam, entry.GetAttributeValue("adminDescription"))})
+	modifyRequest.Replace("info", []string{quarantineStamp(fmt.Sprintf("%s (%s) at %s, formerly %s with sAMAccountName %s", quarantineInfoPrefix, origin, now, entry.DN, sam), entry.GetAttributeValue("info"))})
+
+	if err := c.Modify(modifyRequest); err != nil {
+		// Put the group back where it was, unchanged
+		if _, moveErr := c.RenameObject(quarantinedDN, rdn, parent); moveErr != nil {
+			return "", fmt.Errorf("failed to quarantine group %s: %w; moving it back from %s also failed: %s", dn, err, quarantinedDN, moveErr)
+		}
+		return "", fmt.Errorf("failed to quarantine group %s: %w", dn, err)
+	}
+
+	return quarantinedDN, nil
+}
+
+// quarantineCN returns the CN of a quarantined group: its CN followed by its
+// objectGUID, shortened to fit the schema's limit
+func quarantineCN(cn, guid string) string {
+	suffix := "-" + guid
+	runes := []rune(cn)
+	if limit := maxCNLength - len(suffix); len(runes) > limit {
+		runes = runes[:limit]
+	}
+	return string(runes) + suffix
+}
+
+// quarantineSAMAccountName returns the sAMAccountName of a quarantined group
+func quarantineSAMAccountName(guid string) string {
+	return quarantineSAMPrefix + strings.ReplaceAll(guid, "-", "")
+}
+
+// parseQuarantineStamp reads the quarantine time and the original
+// sAMAccountName from the adminDescription of a quarantined group
+func parseQuarantineStamp(adminDescription string) (time.Time, string, bool) {
+	first, _, _ := strings.Cut(adminDescription, "\n")
+	if !strings.HasPrefix(first, quarantineMarker) {
+		return time.Time{}, "", false
+	}
+
+	at, sam, _ := strings.Cut(strings.TrimPrefix(first, quarantineMarker), " ")
+	quarantinedAt, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+
+	return quarantinedAt, sam, true
+}
+
+// QuarantinedSAMAccountName returns the sAMAccountName a group had before it
+// was quarantined, read from its adminDescription, or "" if it wasn't
+func QuarantinedSAMAccountName(adminDescription string) string {
+	_, sam, _ := parseQuarantineStamp(adminDescription)
+	return sam
+}
+
+// quarantineStamp puts a quarantine stamp on the first line of an attribute
+// value, keeping the previous value below it
+func quarantineStamp(stamp, previous string) string {
+	if previous == "" {
+		return stamp
+	}
+	return stamp + "\n" + previous
+}
+
+// UnquarantineValue removes the stamp QuarantineGroup put on adminDescription
+// or info and returns the previous value
+func UnquarantineValue(value string) string {
+	first, previous, _ := strings.Cut(value, "\n")
+	if strings.HasPrefix(first, quarantineMarker) || strings.HasPrefix(first, quarantineInfoPrefix+" ") {
+		return previous
+	}
+	return value
+}
+
+// ListQuarantinedGroups returns the groups in the quarantine OU that were
+// quarantined before the given time
+func (c *Client) ListQuarantinedGroups(before time.Time) ([]*QuarantinedGroup, error) {
+	if c.quarantineOU == "" {
+		return nil, fmt.Errorf("no quarantine OU is configured")
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		c.quarantineOU,
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&(objectClass=group)(adminDescription=%s*))", EscapeFilter(quarantineMarker)),
+		[]string{"sAMAccountName", "info", "adminDescription", "objectGUID", "objectSid"},
+		nil,
+	)
+
+	result, err := c.SearchPaged(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantined groups: %w", err)
+	}
+
+	groups := make([]*QuarantinedGroup, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		quarantinedAt, sam, ok := parseQuarantineStamp(entry.GetAttributeValue("adminDescription"))
+		if !ok || !quarantinedAt.Before(before) {
+			continue
+		}
+
+		groups = append(groups, &QuarantinedGroup{
+			DN:                     entry.DN,
+			SamAccountName:         entry.GetAttributeValue("sAMAccountName"),
+			OriginalSamAccountName: sam,
+			Info:                   entry.GetAttributeValue("info"),
+			QuarantinedAt:          quarantinedAt,
+			ObjectGUID:             FormatGUID(entry.GetRawAttributeValue("objectGUID")),
+			ObjectSid:              FormatSID(entry.GetRawAttributeValue("objectSid")),
+		})
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuarantineCN(t *testing.T) {
+	const guid = "0f8a7e2c-1b3d-4c5e-9f60-718293a4b5c6"
+
+	tests := map[string]string{
+		"short":      "helpdesk",
+		"at limit":   strings.Repeat("a", maxCNLength-len(guid)-1),
+		"too long":   strings.Repeat("a", maxCNLength),
+		"multi-byte": strings.Repeat("ä", maxCNLength),
+	}
+
+	for name, cn := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := quarantineCN(cn, guid)
+			if !strings.HasSuffix(got, "-"+guid) {
+				t.Errorf("quarantineCN(%q) = %q, want the GUID as suffix", cn, got)
+			}
+			if n := len([]rune(got)); n > maxCNLength {
+				t.Errorf("quarantineCN(%q) has %d characters, want at most %d", cn, n, maxCNLength)
+			}
+			if prefix := strings.TrimSuffix(got, "-"+guid); !strings.HasPrefix(cn, prefix) {
+				t.Errorf("quarantineCN(%q) = %q, want a prefix of the CN", cn, got)
+			}
+		})
+	}
+}
+
+func TestUnquarantineValue(t *testing.T) {
+	stamp := quarantineMarker + "2026-01-02T03:04:05Z"
+	info := quarantineInfoPrefix + " (adgroups_group) at 2026-01-02T03:04:05Z, formerly CN=g,OU=Groups,DC=example,DC=com"
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"stamp only", quarantineStamp(stamp, ""), ""},
+		{"stamp and previous value", quarantineStamp(stamp, "Tier 1"), "Tier 1"},
+		{"multi-line previous value", quarantineStamp(info, "first\nsecond"), "first\nsecond"},
+		{"not stamped", "Tier 1", "Tier 1"},
+		{"not stamped multi-line", "first\nsecond", "first\nsecond"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnquarantineValue(tt.value); got != tt.want {
+				t.Errorf("UnquarantineValue(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuarantineSAMAccountName(t *testing.T) {
+	guids := []string{
+		"0f8a7e2c-1b3d-4c5e-9f60-718293a4b5c6",
+		"0f8a7e2c-1b3d-4c5e-9f60-718293a4b5c7",
+	}
+
+	first, second := quarantineSAMAccountName(guids[0]), quarantineSAMAccountName(guids[1])
+	if first == second {
+		t.Errorf("quarantineSAMAccountName gives %q for different GUIDs", first)
+	}
+	for _, sam := range []string{first, second} {
+		if !strings.HasPrefix(sam, quarantineSAMPrefix) {
+			t.Errorf("quarantineSAMAccountName = %q, want prefix %q", sam, quarantineSAMPrefix)
+		}
+		// Characters SAM rejects in account names
+		if strings.ContainsAny(sam, `"/\[]:;|=,+*?<>@ `) {
+			t.Errorf("quarantineSAMAccountName = %q contains invalid characters", sam)
+		}
+	}
+}
+
+func TestParseQuarantineStamp(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		sam   string
+		ok    bool
+	}{
+		{"stamp", quarantineMarker + "2026-01-02T03:04:05Z helpdesk", "helpdesk", true},
+		{"name with spaces", quarantineMarker + "2026-01-02T03:04:05Z Help Desk Admins", "Help Desk Admins", true},
+		{"previous value kept", quarantineStamp(quarantineMarker+"2026-01-02T03:04:05Z helpdesk", "Tier 1"), "helpdesk", true},
+		{"without name", quarantineMarker + "2026-01-02T03:04:05Z", "", true},
+		{"invalid time", quarantineMarker + "yesterday helpdesk", "", false},
+		{"not stamped", "Tier 1", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, sam, ok := parseQuarantineStamp(tt.value)
+			if ok != tt.ok || sam != tt.sam {
+				t.Fatalf("parseQuarantineStamp(%q) = %q, %t, want %q, %t", tt.value, sam, ok, tt.sam, tt.ok)
+			}
+			if ok && !at.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+				t.Errorf("parseQuarantineStamp(%q) time = %s", tt.value, at)
+			}
+			if got := QuarantinedSAMAccountName(tt.value); got != tt.sam {
+				t.Errorf("QuarantinedSAMAccountName(%q) = %q, want %q", tt.value, got, tt.sam)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// DeletedGroup is a deleted group in the Deleted Objects container, which
+// can be restored with its SID and (with the Recycle Bin enabled) its
+// attributes and memberships
+type DeletedGroup struct {
+	DN              string `json:"dn"`
+	SamAccountName  string `json:"sam_account_name"`
+	LastKnownParent string `json:"last_known_parent"`
+	WhenChanged     string `json:"when_changed"`
+	ObjectGUID      string `json:"object_guid"`
+}
+
+// FindDeletedGroup finds the most recently deleted group with the given
+// sAMAccountName in the Deleted Objects container
+func (c *Client) FindDeletedGroup(samAccountName string) (*DeletedGroup, error) {
+	group, err := c.findDeletedGroup(fmt.Sprintf("(sAMAccountName=%s)", EscapeFilter(samAccountName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deleted group %s: %w", samAccountName, err)
+	}
+
+	return group, nil
+}
+
+// FindDeletedGroupByGUID finds the deleted group with the given objectGUID
+// in the Deleted Objects container
+func (c *Client) FindDeletedGroupByGUID(guid string) (*DeletedGroup, error) {
+	raw, err := ParseGUID(guid)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := c.findDeletedGroup(fmt.Sprintf("(objectGUID=%s)", escapeFilterBytes(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deleted group with GUID %s: %w", guid, err)
+	}
+
+	return group, nil
+}
+
+// findDeletedGroup returns the most recently deleted group matching the
+// filter
+func (c *Client) findDeletedGroup(filter string) (*DeletedGroup, error) {
+	domainDN, err := c.DefaultNamingContext()
+	if err != nil {
+		return nil, err
+	}
+
+	// Recycled objects have lost their attributes and can't be restored
+	searchRequest := ldap.NewSearchRequest(
+		"CN=Deleted Objects,"+domainDN,
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&(objectClass=group)(isDeleted=TRUE)(!(isRecycled=TRUE))%s)", filter),
+		[]string{"sAMAccountName", "lastKnownParent", "whenChanged", "objectGUID"},
+		[]ldap.Control{NewControlShowDeleted()},
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for deleted groups: %w", err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("deleted group not found")
+	}
+
+	// GeneralizedTime values sort chronologically as strings
+	sort.Slice(result.Entries, func(i, j int) bool {
+		return result.Entries[i].GetAttributeValue("whenChanged") > result.Entries[j].GetAttributeValue("whenChanged")
+	})
+
+	entry := result.Entries[0]
+	return &DeletedGroup{
+		DN:              entry.DN,
+		SamAccountName:  entry.GetAttributeValue("sAMAccountName"),
+		LastKnownParent: entry.GetAttributeValue("lastKnownParent"),
+		WhenChanged:     FormatGeneralizedTime(entry.GetAttributeValue("whenChanged")),
+		ObjectGUID:      FormatGUID(entry.GetRawAttributeValue("objectGUID")),
+	}, nil
+}
+
+// RestoreDeletedObject reanimates a deleted object by removing isDeleted
+// and giving it a new distinguished name, in a single modify operation
+func (c *Client) RestoreDeletedObject(deletedDN, newDN string) error {
+	modifyRequest := ldap.NewModifyRequest(deletedDN, []ldap.Control{NewControlShowDeleted()})
+	modifyRequest.Delete("isDeleted", []string{})
+	modifyRequest.Replace("distinguishedName", []string{newDN})
+
+	err := c.Modify(modifyRequest)
+	if err != nil {
+		return fmt.Errorf("failed to restore deleted object %s as %s: %w", deletedDN, newDN, err)
+	}
+
+	return nil
+}
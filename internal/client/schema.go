@@ -0,0 +1,183 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// systemFlags bits on attributeSchema objects
+const (
+	systemFlagAttrIsConstructed = 0x00000004
+)
+
+// AttributeSchema describes an attribute definition from the AD schema
+type AttributeSchema struct {
+	Name         string
+	SingleValued bool
+	SystemOnly   bool
+	Constructed  bool
+	BackLink     bool
+}
+
+// ReadOnly reports whether clients can never write the attribute
+func (a *AttributeSchema) ReadOnly() bool {
+	return a.SystemOnly || a.Constructed || a.BackLink
+}
+
+// GetAttributeSchema looks up an attribute definition in the schema by its
+// lDAPDisplayName. Results are cached for the lifetime of the client.
+func (c *Client) GetAttributeSchema(name string) (*AttributeSchema, error) {
+	key := strings.ToLower(name)
+
+	c.schemaMu.Lock()
+	defer c.schemaMu.Unlock()
+
+	if attribute, ok := c.schemaCache[key]; ok {
+		return attribute, nil
+	}
+
+	schemaDN, err := c.schemaNamingContext()
+	if err != nil {
+		return nil, err
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		schemaDN,
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&(objectClass=attributeSchema)(lDAPDisplayName=%s))", EscapeFilter(name)),
+		[]string{"lDAPDisplayName", "isSingleValued", "systemOnly", "systemFlags", "linkID"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search schema for attribute %s: %w", name, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("attribute not found in schema: %s", name)
+	}
+
+	entry := result.Entries[0]
+	systemFlags, _ := strconv.ParseInt(entry.GetAttributeValue("systemFlags"), 10, 64)
+	linkID, _ := strconv.ParseInt(entry.GetAttributeValue("linkID"), 10, 64)
+
+	attribute := &AttributeSchema{
+		Name:         entry.GetAttributeValue("lDAPDisplayName"),
+		SingleValued: ParseBoolean(entry.GetAttributeValue("isSingleValued")),
+		SystemOnly:   ParseBoolean(entry.GetAttributeValue("systemOnly")),
+		Constructed:  systemFlags&systemFlagAttrIsConstructed != 0,
+		// Back links have odd link IDs and are maintained by the server
+		BackLink: linkID%2 == 1,
+	}
+
+	if c.schemaCache == nil {
+		c.schemaCache = make(map[string]*AttributeSchema)
+	}
+	c.schemaCache[key] = attribute
+
+	return attribute, nil
+}
+
+// schemaNamingContext returns the DN of the schema partition from the
+// RootDSE. The caller must hold schemaMu.
+func (c *Client) schemaNamingContext() (string, error) {
+	if c.schemaDN != "" {
+		return c.schemaDN, nil
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		"",
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=*)",
+		[]string{"schemaNamingContext"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to read RootDSE: %w", err)
+	}
+
+	if len(result.Entries) == 0 || result.Entries[0].GetAttributeValue("schemaNamingContext") == "" {
+		return "", fmt.Errorf("RootDSE has no schemaNamingContext")
+	}
+
+	c.schemaDN = result.Entries[0].GetAttributeValue("schemaNamingContext")
+	return c.schemaDN, nil
+}
+
+// DefaultNamingContext returns the DN of the domain partition from the
+// RootDSE
+func (c *Client) DefaultNamingContext() (string, error) {
+	searchRequest := ldap.NewSearchRequest(
+		"",
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=*)",
+		[]string{"defaultNamingContext"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to read RootDSE: %w", err)
+	}
+
+	if len(result.Entries) == 0 || result.Entries[0].GetAttributeValue("defaultNamingContext") == "" {
+		return "", fmt.Errorf("RootDSE has no defaultNamingContext")
+	}
+
+	return result.Entries[0].GetAttributeValue("defaultNamingContext"), nil
+}
+
+// GetAttributes reads the given attributes of an object. Every requested
+// attribute is present in the result; attributes without values map to an
+// empty slice.
+func (c *Client) GetAttributes(dn string, names []string) (map[string][]string, error) {
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=*)",
+		names,
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attributes of %s: %w", dn, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("object not found: %s", dn)
+	}
+
+	attributes := make(map[string][]string, len(names))
+	for _, name := range names {
+		values := result.Entries[0].GetEqualFoldAttributeValues(name)
+		if values == nil {
+			values = []string{}
+		}
+		attributes[name] = values
+	}
+
+	return attributes, nil
+}
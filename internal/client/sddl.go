@@ -0,0 +1,233 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sddlACETypes maps ACE types to their SDDL codes
+var sddlACETypes = map[byte]string{
+	ACETypeAccessAllowed:       "A",
+	ACETypeAccessDenied:        "D",
+	ACETypeAccessAllowedObject: "OA",
+	ACETypeAccessDeniedObject:  "OD",
+}
+
+// sddlACEFlags lists the ACE flags with their SDDL codes, in the order
+// Windows writes them
+var sddlACEFlags = []struct {
+	flag byte
+	code string
+}{
+	{ACEFlagContainerInherit, "CI"},
+	{ACEFlagObjectInherit, "OI"},
+	{ACEFlagNoPropagate, "NP"},
+	{ACEFlagInheritOnly, "IO"},
+	{ACEFlagInherited, "ID"},
+}
+
+// sddlRights lists the directory service access rights with their SDDL
+// codes, in the order Windows writes them
+var sddlRights = []struct {
+	right uint32
+	code  string
+}{
+	{RightDSCreateChild, "CC"},
+	{RightDSDeleteChild, "DC"},
+	{RightDSListChildren, "LC"},
+	{RightDSSelf, "SW"},
+	{RightDSReadProperty, "RP"},
+	{RightDSWriteProperty, "WP"},
+	{RightDSDeleteTree, "DT"},
+	{RightDSListObject, "LO"},
+	{RightDSControlAccess, "CR"},
+	{RightDelete, "SD"},
+	{RightReadControl, "RC"},
+	{RightWriteDAC, "WD"},
+	{RightWriteOwner, "WO"},
+}
+
+// Generic rights as Active Directory maps them to directory service rights
+const (
+	RightGenericRead  = RightReadControl | RightDSListChildren | RightDSReadProperty | RightDSListObject
+	RightGenericWrite = RightReadControl | RightDSWriteProperty | RightDSSelf
+)
+
+// sddlGenericRights maps the generic SDDL right codes to the rights Active
+// Directory stores for them
+var sddlGenericRights = map[string]uint32{
+	"GA": RightGenericAll,
+	"GR": RightGenericRead,
+	"GW": RightGenericWrite,
+}
+
+// sddlSIDAliases maps common SDDL SID aliases to their SIDs
+var sddlSIDAliases = map[string]string{
+	"WD": SIDEveryone,
+	"CO": "S-1-3-0",
+	"PS": "S-1-5-10",
+	"AU": "S-1-5-11",
+	"SY": "S-1-5-18",
+	"BA": SIDBuiltinAdministrators,
+}
+
+// SDDL returns the SDDL string of the ACE, e.g.
+// "(OA;CI;WP;bf9679c0-0de6-11d0-a285-00aa003049e2;;S-1-5-21-...-1105)".
+// SIDs are always written in full. ACEs of other types than (object)
+// allowed and denied have no SDDL form and yield "".
+func (a *ACE) SDDL() string {
+	aceType, ok := sddlACETypes[a.Type]
+	if !ok || a.raw != nil {
+		return ""
+	}
+
+	var flags strings.Builder
+	for _, f := range sddlACEFlags {
+		if a.Flags&f.flag != 0 {
+			flags.WriteString(f.code)
+		}
+	}
+
+	return fmt.Sprintf("(%s;%s;%s;%s;%s;%s)",
+		aceType, flags.String(), sddlRightsString(a.Mask), a.ObjectType, a.InheritedObjectType, a.SID)
+}
+
+// sddlRightsString formats an access mask as SDDL right codes, or as a hex
+// value if it contains rights without a code
+func sddlRightsString(mask uint32) string {
+	var codes strings.Builder
+	remaining := mask
+	for _, r := range sddlRights {
+		if mask&r.right != 0 {
+			codes.WriteString(r.code)
+			remaining &^= r.right
+		}
+	}
+
+	if remaining != 0 {
+		return fmt.Sprintf("0x%x", mask)
+	}
+	return codes.String()
+}
+
+// ParseACESDDL parses a single ACE in SDDL form, the inverse of ACE.SDDL.
+// Rights may be given as codes or as a hex value, and a few common SID
+// aliases such as WD and BA are accepted.
+func ParseACESDDL(sddl string) (*ACE, error) {
+	trimmed := strings.TrimSpace(sddl)
+	if !strings.HasPrefix(trimmed, "(") || !strings.HasSuffix(trimmed, ")") {
+		return nil, fmt.Errorf("invalid ACE %q: expected (type;flags;rights;object;inherited object;sid)", sddl)
+	}
+
+	fields := strings.Split(trimmed[1:len(trimmed)-1], ";")
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("invalid ACE %q: expected 6 fields, got %d", sddl, len(fields))
+	}
+
+	ace := &ACE{}
+
+	aceType := strings.ToUpper(fields[0])
+	found := false
+	for t, code := range sddlACETypes {
+		if code == aceType {
+			ace.Type, found = t, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("invalid ACE %q: unsupported type %q", sddl, fields[0])
+	}
+
+	flags := strings.ToUpper(fields[1])
+	for len(flags) > 0 {
+		matched := false
+		for _, f := range sddlACEFlags {
+			if strings.HasPrefix(flags, f.code) {
+				ace.Flags |= f.flag
+				flags = flags[len(f.code):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("invalid ACE %q: unknown flags %q", sddl, flags)
+		}
+	}
+
+	mask, err := parseSDDLRights(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACE %q: %w", sddl, err)
+	}
+	ace.Mask = mask
+
+	for _, guid := range []struct {
+		value  string
+		target *string
+	}{
+		{fields[3], &ace.ObjectType},
+		{fields[4], &ace.InheritedObjectType},
+	} {
+		if guid.value == "" {
+			continue
+		}
+		if _, err := ParseGUID(guid.value); err != nil {
+			return nil, fmt.Errorf("invalid ACE %q: %w", sddl, err)
+		}
+		*guid.target = strings.ToLower(strings.Trim(guid.value, "{}"))
+	}
+
+	if !ace.IsObjectACE() && (ace.ObjectType != "" || ace.InheritedObjectType != "") {
+		return nil, fmt.Errorf("invalid ACE %q: only object ACEs (OA, OD) can have object types", sddl)
+	}
+
+	ace.SID = fields[5]
+	if sid, ok := sddlSIDAliases[strings.ToUpper(ace.SID)]; ok {
+		ace.SID = sid
+	}
+	if _, err := ParseSID(ace.SID); err != nil {
+		return nil, fmt.Errorf("invalid ACE %q: %w", sddl, err)
+	}
+	ace.SID = strings.ToUpper(ace.SID[:1]) + ace.SID[1:]
+
+	return ace, nil
+}
+
+// parseSDDLRights parses SDDL right codes or a hex access mask
+func parseSDDLRights(value string) (uint32, error) {
+	if strings.HasPrefix(strings.ToLower(value), "0x") {
+		mask, err := strconv.ParseUint(value[2:], 16, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid access mask %q", value)
+		}
+		return uint32(mask), nil
+	}
+
+	var mask uint32
+	codes := strings.ToUpper(value)
+	for i := 0; i+2 <= len(codes); i += 2 {
+		code := codes[i : i+2]
+		if right, ok := sddlGenericRights[code]; ok {
+			mask |= right
+			continue
+		}
+
+		matched := false
+		for _, r := range sddlRights {
+			if r.code == code {
+				mask |= r.right
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return 0, fmt.Errorf("unknown right %q", code)
+		}
+	}
+
+	if len(codes)%2 != 0 || mask == 0 {
+		return 0, fmt.Errorf("invalid rights %q", value)
+	}
+
+	return mask, nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import "testing"
+
+func TestACESDDLRoundTrip(t *testing.T) {
+	tests := []string{
+		"(A;;RPWP;;;S-1-5-21-1004336348-1177238915-682003330-1105)",
+		"(D;;DTSD;;;S-1-1-0)",
+		"(OA;;WP;bf9679c0-0de6-11d0-a285-00aa003049e2;;S-1-5-21-1004336348-1177238915-682003330-1105)",
+		"(OA;CIIO;WP;bf9679c0-0de6-11d0-a285-00aa003049e2;bf967a9c-0de6-11d0-a285-00aa003049e2;S-1-5-21-1004336348-1177238915-682003330-1105)",
+		"(OD;CINP;CR;00299570-246d-11d0-a768-00aa006e0529;;S-1-5-11)",
+		"(OA;CI;CCDC;;bf967aba-0de6-11d0-a285-00aa003049e2;S-1-5-32-544)",
+		"(A;CIOINPIOID;CCDCLCSWRPWPDTLOCRSDRCWDWO;;;S-1-5-18)",
+		// Rights without an SDDL code are written as a hex mask
+		"(A;;0x10000000;;;S-1-5-18)",
+	}
+
+	for _, sddl := range tests {
+		ace, err := ParseACESDDL(sddl)
+		if err != nil {
+			t.Fatalf("ParseACESDDL(%q) returned error: %s", sddl, err)
+		}
+		if formatted := ace.SDDL(); formatted != sddl {
+			t.Errorf("ParseACESDDL(%q).SDDL() = %q", sddl, formatted)
+		}
+
+		// The binary form must survive a round trip as well
+		encoded, err := ace.bytes()
+		if err != nil {
+			t.Fatalf("encoding %q returned error: %s", sddl, err)
+		}
+		decoded, err := parseACE(encoded)
+		if err != nil {
+			t.Fatalf("decoding %q returned error: %s", sddl, err)
+		}
+		if !decoded.Equal(ace) {
+			t.Errorf("binary round trip of %q gave %q", sddl, decoded.SDDL())
+		}
+	}
+}
+
+func TestParseACESDDLNormalizes(t *testing.T) {
+	tests := []struct {
+		sddl string
+		want string
+	}{
+		{"(A;;RP;;;WD)", "(A;;RP;;;S-1-1-0)"},
+		{"(a;ci;rpwp;;;ba)", "(A;CI;RPWP;;;S-1-5-32-544)"},
+		{"(A;;GA;;;SY)", "(A;;CCDCLCSWRPWPDTLOCRSDRCWDWO;;;S-1-5-18)"},
+		{"(A;;GR;;;AU)", "(A;;LCRPLORC;;;S-1-5-11)"},
+		{"(A;;GW;;;AU)", "(A;;SWWPRC;;;S-1-5-11)"},
+		{"(A;;0x30;;;AU)", "(A;;RPWP;;;S-1-5-11)"},
+		{"(OA;;WP;{BF9679C0-0DE6-11D0-A285-00AA003049E2};;s-1-5-11)", "(OA;;WP;bf9679c0-0de6-11d0-a285-00aa003049e2;;S-1-5-11)"},
+		{" (D;IOCI;SD;;;WD) ", "(D;CIIO;SD;;;S-1-1-0)"},
+	}
+
+	for _, tt := range tests {
+		ace, err := ParseACESDDL(tt.sddl)
+		if err != nil {
+			t.Fatalf("ParseACESDDL(%q) returned error: %s", tt.sddl, err)
+		}
+		if formatted := ace.SDDL(); formatted != tt.want {
+			t.Errorf("ParseACESDDL(%q).SDDL() = %q, want %q", tt.sddl, formatted, tt.want)
+		}
+	}
+}
+
+func TestParseACESDDLInvalid(t *testing.T) {
+	for _, sddl := range []string{
+		"",
+		"A;;RP;;;WD",
+		"(A;;RP;;WD)",
+		"(A;;RP;;;WD;)",
+		"(AU;;RP;;;WD)",
+		"(A;XX;RP;;;WD)",
+		"(A;;;;;WD)",
+		"(A;;R;;;WD)",
+		"(A;;ZZ;;;WD)",
+		"(A;;0xnope;;;WD)",
+		"(A;;RP;bf9679c0-0de6-11d0-a285-00aa003049e2;;WD)",
+		"(OA;;RP;not-a-guid;;WD)",
+		"(A;;RP;;;CN=Someone,DC=example,DC=com)",
+		"(A;;RP;;;XX)",
+	} {
+		if ace, err := ParseACESDDL(sddl); err == nil {
+			t.Errorf("ParseACESDDL(%q) = %q, want error", sddl, ace.SDDL())
+		}
+	}
+}
@@ -0,0 +1,632 @@
+package client
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// ControlTypeSDFlags - https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-adts/3888c2b7-35b9-45b7-afeb-b772aa932dd0
+	ControlTypeSDFlags = "1.2.840.113556.1.4.801"
+
+	// sdFlagsDACL selects the DACL part of nTSecurityDescriptor
+	sdFlagsDACL = 0x4
+)
+
+// ACE types
+const (
+	ACETypeAccessAllowed       = 0x00
+	ACETypeAccessDenied        = 0x01
+	ACETypeAccessAllowedObject = 0x05
+	ACETypeAccessDeniedObject  = 0x06
+)
+
+// ACE flags
+const (
+	ACEFlagObjectInherit    = 0x01
+	ACEFlagContainerInherit = 0x02
+	ACEFlagNoPropagate      = 0x04
+	ACEFlagInheritOnly      = 0x08
+	ACEFlagInherited        = 0x10
+)
+
+// Object ACE flags, telling which GUIDs are present
+const (
+	aceObjectTypePresent          = 0x1
+	aceInheritedObjectTypePresent = 0x2
+)
+
+// Access rights used in directory service ACEs
+const (
+	RightDSCreateChild   = 0x00000001
+	RightDSDeleteChild   = 0x00000002
+	RightDSListChildren  = 0x00000004
+	RightDSSelf          = 0x00000008
+	RightDSReadProperty  = 0x00000010
+	RightDSWriteProperty = 0x00000020
+	RightDSDeleteTree    = 0x00000040
+	RightDSListObject    = 0x00000080
+	RightDSControlAccess = 0x00000100
+	RightDelete          = 0x00010000
+	RightReadControl     = 0x00020000
+	RightWriteDAC        = 0x00040000
+	RightWriteOwner      = 0x00080000
+	RightGenericAll      = 0x000f01ff
+)
+
+// SIDEveryone is the well-known SID of the Everyone group
+const SIDEveryone = "S-1-1-0"
+
+// securityDescriptorControlDACLPresent is SE_DACL_PRESENT
+const securityDescriptorControlDACLPresent = 0x0004
+
+// NewControlSDFlags returns a control that limits reads and writes of
+// nTSecurityDescriptor to the given parts, so that callers without rights on
+// the owner or SACL can still manage the DACL
+func NewControlSDFlags(flags int64) ldap.Control {
+	return &flagsControl{controlType: ControlTypeSDFlags, criticality: true, flags: flags}
+}
+
+// ACE is an access control entry. Object ACEs carry the GUIDs of the
+// property, property set, extended right or child class they apply to.
+// ACEs of other types are kept as raw bytes so they survive a round trip.
+type ACE struct {
+	Type                byte
+	Flags               byte
+	Mask                uint32
+	ObjectType          string
+	InheritedObjectType string
+	SID                 string
+
+	raw []byte
+}
+
+// Inherited reports whether the ACE was inherited from a parent object
+func (a *ACE) Inherited() bool {
+	return a.Flags&ACEFlagInherited != 0
+}
+
+// IsObjectACE reports whether the ACE is an object ACE
+func (a *ACE) IsObjectACE() bool {
+	return a.Type == ACETypeAccessAllowedObject || a.Type == ACETypeAccessDeniedObject
+}
+
+// IsDeny reports whether the ACE denies access
+func (a *ACE) IsDeny() bool {
+	return a.Type == ACETypeAccessDenied || a.Type == ACETypeAccessDeniedObject
+}
+
+// ACL is an access control list
+type ACL struct {
+	Revision byte
+	ACEs     []*ACE
+}
+
+// SecurityDescriptor is a self-relative security descriptor. Only the DACL
+// is decoded; the other parts are kept as raw bytes.
+type SecurityDescriptor struct {
+	Revision byte
+	Control  uint16
+	Owner    []byte
+	Group    []byte
+	SACL     []byte
+	DACL     *ACL
+}
+
+// ParseSecurityDescriptor decodes a self-relative security descriptor
+func ParseSecurityDescriptor(raw []byte) (*SecurityDescriptor, error) {
+	if len(raw) < 20 {
+		return nil, fmt.Errorf("security descriptor is too short")
+	}
+
+	sd := &SecurityDescriptor{
+		Revision: raw[0],
+		Control:  binary.LittleEndian.Uint16(raw[2:4]),
+	}
+
+	offsetOwner := binary.LittleEndian.Uint32(raw[4:8])
+	offsetGroup := binary.LittleEndian.Uint32(raw[8:12])
+	offsetSACL := binary.LittleEndian.Uint32(raw[12:16])
+	offsetDACL := binary.LittleEndian.Uint32(raw[16:20])
+
+	var err error
+	if sd.Owner, err = sidAt(raw, offsetOwner); err != nil {
+		return nil, fmt.Errorf("invalid owner: %w", err)
+	}
+	if sd.Group, err = sidAt(raw, offsetGroup); err != nil {
+		return nil, fmt.Errorf("invalid group: %w", err)
+	}
+
+	if offsetSACL != 0 {
+		sacl, err := aclAt(raw, offsetSACL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SACL: %w", err)
+		}
+		sd.SACL = append([]byte(nil), sacl...)
+	}
+
+	if offsetDACL != 0 {
+		dacl, err := aclAt(raw, offsetDACL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DACL: %w", err)
+		}
+		if sd.DACL, err = parseACL(dacl); err != nil {
+			return nil, fmt.Errorf("invalid DACL: %w", err)
+		}
+	}
+
+	return sd, nil
+}
+
+// sidAt returns the raw SID at the given offset, or nil for offset 0
+func sidAt(raw []byte, offset uint32) ([]byte, error) {
+	if offset == 0 {
+		return nil, nil
+	}
+	if uint64(offset)+8 > uint64(len(raw)) {
+		return nil, fmt.Errorf("offset %d out of range", offset)
+	}
+
+	size := uint64(8 + 4*int(raw[offset+1]))
+	if uint64(offset)+size > uint64(len(raw)) {
+		return nil, fmt.Errorf("size %d out of range", size)
+	}
+
+	return append([]byte(nil), raw[offset:uint64(offset)+size]...), nil
+}
+
+// aclAt returns the raw ACL at the given offset, checking that its header
+// and its size lie within the security descriptor
+func aclAt(raw []byte, offset uint32) ([]byte, error) {
+	if uint64(offset)+8 > uint64(len(raw)) {
+		return nil, fmt.Errorf("offset %d out of range", offset)
+	}
+
+	size := uint64(binary.LittleEndian.Uint16(raw[offset+2 : offset+4]))
+	if uint64(offset)+size > uint64(len(raw)) {
+		return nil, fmt.Errorf("size %d out of range", size)
+	}
+
+	return raw[offset : uint64(offset)+size], nil
+}
+
+// parseACL decodes an ACL
+func parseACL(raw []byte) (*ACL, error) {
+	if len(raw) < 8 {
+		return nil, fmt.Errorf("ACL is too short")
+	}
+
+	size := int(binary.LittleEndian.Uint16(raw[2:4]))
+	count := int(binary.LittleEndian.Uint16(raw[4:6]))
+	if size < 8 || size > len(raw) {
+		return nil, fmt.Errorf("ACL size out of range")
+	}
+
+	acl := &ACL{Revision: raw[0]}
+	offset := 8
+	for i := 0; i < count; i++ {
+		if offset+4 > size {
+			return nil, fmt.Errorf("ACE %d out of range", i)
+		}
+
+		aceSize := int(binary.LittleEndian.Uint16(raw[offset+2 : offset+4]))
+		if aceSize < 4 || offset+aceSize > size {
+			return nil, fmt.Errorf("ACE %d has an invalid size", i)
+		}
+
+		ace, err := parseACE(raw[offset : offset+aceSize])
+		if err != nil {
+			return nil, fmt.Errorf("ACE %d: %w", i, err)
+		}
+		acl.ACEs = append(acl.ACEs, ace)
+		offset += aceSize
+	}
+
+	return acl, nil
+}
+
+// parseACE decodes an ACE. Types other than (object) allowed and denied
+// are kept raw.
+func parseACE(raw []byte) (*ACE, error) {
+	ace := &ACE{Type: raw[0], Flags: raw[1]}
+
+	switch ace.Type {
+	case ACETypeAccessAllowed, ACETypeAccessDenied:
+		if len(raw) < 8 {
+			return nil, fmt.Errorf("ACE is too short")
+		}
+		ace.Mask = binary.LittleEndian.Uint32(raw[4:8])
+		ace.SID = FormatSID(trimSID(raw[8:]))
+
+	case ACETypeAccessAllowedObject, ACETypeAccessDeniedObject:
+		if len(raw) < 12 {
+			return nil, fmt.Errorf("ACE is too short")
+		}
+		ace.Mask = binary.LittleEndian.Uint32(raw[4:8])
+		objectFlags := binary.LittleEndian.Uint32(raw[8:12])
+
+		offset := 12
+		if objectFlags&aceObjectTypePresent != 0 {
+			if offset+16 > len(raw) {
+				return nil, fmt.Errorf("ACE is too short")
+			}
+			ace.ObjectType = FormatGUID(raw[offset : offset+16])
+			offset += 16
+		}
+		if objectFlags&aceInheritedObjectTypePresent != 0 {
+			if offset+16 > len(raw) {
+				return nil, fmt.Errorf("ACE is too short")
+			}
+			ace.InheritedObjectType = FormatGUID(raw[offset : offset+16])
+			offset += 16
+		}
+		ace.SID = FormatSID(trimSID(raw[offset:]))
+
+	default:
+		ace.raw = append([]byte(nil), raw...)
+		return ace, nil
+	}
+
+	if ace.SID == "" {
+		return nil, fmt.Errorf("ACE has an invalid SID")
+	}
+
+	return ace, nil
+}
+
+// trimSID trims trailing padding after a raw SID
+func trimSID(raw []byte) []byte {
+	if len(raw) < 8 {
+		return raw
+	}
+
+	size := 8 + 4*int(raw[1])
+	if size > len(raw) {
+		return raw
+	}
+
+	return raw[:size]
+}
+
+// Bytes encodes the security descriptor in self-relative form
+func (sd *SecurityDescriptor) Bytes() ([]byte, error) {
+	var dacl []byte
+	if sd.DACL != nil {
+		var err error
+		if dacl, err = sd.DACL.bytes(); err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]byte, 20)
+	out[0] = sd.Revision
+	control := sd.Control
+	if sd.DACL != nil {
+		control |= securityDescriptorControlDACLPresent
+	}
+	binary.LittleEndian.PutUint16(out[2:4], control)
+
+	// Parts are laid out in the usual order: SACL, DACL, owner, group
+	for _, part := range []struct {
+		offset int
+		data   []byte
+	}{
+		{12, sd.SACL},
+		{16, dacl},
+		{4, sd.Owner},
+		{8, sd.Group},
+	} {
+		if len(part.data) == 0 {
+			continue
+		}
+		binary.LittleEndian.PutUint32(out[part.offset:part.offset+4], uint32(len(out)))
+		out = append(out, part.data...)
+	}
+
+	return out, nil
+}
+
+// bytes encodes the ACL
+func (acl *ACL) bytes() ([]byte, error) {
+	out := make([]byte, 8)
+	out[0] = acl.Revision
+	if out[0] == 0 {
+		out[0] = 4
+	}
+
+	for _, ace := range acl.ACEs {
+		encoded, err := ace.bytes()
+		if err != nil {
+			return nil, err
+		}
+		// Object ACEs require ACL revision 4
+		if ace.IsObjectACE() {
+			out[0] = 4
+		}
+		out = append(out, encoded...)
+	}
+
+	binary.LittleEndian.PutUint16(out[2:4], uint16(len(out)))
+	binary.LittleEndian.PutUint16(out[4:6], uint16(len(acl.ACEs)))
+
+	return out, nil
+}
+
+// bytes encodes the ACE
+func (a *ACE) bytes() ([]byte, error) {
+	if a.raw != nil {
+		return a.raw, nil
+	}
+
+	sid, err := ParseSID(a.SID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []byte{a.Type, a.Flags, 0, 0, 0, 0, 0, 0}
+	binary.LittleEndian.PutUint32(out[4:8], a.Mask)
+
+	if a.IsObjectACE() {
+		var objectFlags uint32
+		var guids []byte
+
+		if a.ObjectType != "" {
+			guid, err := ParseGUID(a.ObjectType)
+			if err != nil {
+				return nil, err
+			}
+			objectFlags |= aceObjectTypePresent
+			guids = append(guids, guid...)
+		}
+		if a.InheritedObjectType != "" {
+			guid, err := ParseGUID(a.InheritedObjectType)
+			if err != nil {
+				return nil, err
+			}
+			objectFlags |= aceInheritedObjectTypePresent
+			guids = append(guids, guid...)
+		}
+
+		out = binary.LittleEndian.AppendUint32(out, objectFlags)
+		out = append(out, guids...)
+	}
+
+	out = append(out, sid...)
+	binary.LittleEndian.PutUint16(out[2:4], uint16(len(out)))
+
+	return out, nil
+}
+
+// ParseSID converts a SID string (e.g. "S-1-5-32-544") to its binary form
+func ParseSID(sid string) ([]byte, error) {
+	parts := strings.Split(sid, "-")
+	if len(parts) < 3 || !strings.EqualFold(parts[0], "S") {
+		return nil, fmt.Errorf("invalid SID %q", sid)
+	}
+
+	revision, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SID %q: %w", sid, err)
+	}
+
+	authority, err := strconv.ParseUint(parts[2], 10, 48)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SID %q: %w", sid, err)
+	}
+
+	subAuthorities := parts[3:]
+	if len(subAuthorities) > 15 {
+		return nil, fmt.Errorf("invalid SID %q: too many sub-authorities", sid)
+	}
+
+	out := make([]byte, 8, 8+4*len(subAuthorities))
+	out[0] = byte(revision)
+	out[1] = byte(len(subAuthorities))
+	for i := 0; i < 6; i++ {
+		out[7-i] = byte(authority >> (8 * i))
+	}
+
+	for _, part := range subAuthorities {
+		value, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SID %q: %w", sid, err)
+		}
+		out = binary.LittleEndian.AppendUint32(out, uint32(value))
+	}
+
+	return out, nil
+}
+
+// ParseGUID converts a GUID string to its binary objectGUID form, the
+// inverse of FormatGUID
+func ParseGUID(guid string) ([]byte, error) {
+	raw, err := hex.DecodeString(strings.ReplaceAll(strings.Trim(guid, "{}"), "-", ""))
+	if err != nil || len(raw) != 16 {
+		return nil, fmt.Errorf("invalid GUID %q", guid)
+	}
+
+	// The first three components are stored little-endian
+	out := make([]byte, 16)
+	binary.LittleEndian.PutUint32(out[0:4], binary.BigEndian.Uint32(raw[0:4]))
+	binary.LittleEndian.PutUint16(out[4:6], binary.BigEndian.Uint16(raw[4:6]))
+	binary.LittleEndian.PutUint16(out[6:8], binary.BigEndian.Uint16(raw[6:8]))
+	copy(out[8:], raw[8:])
+
+	return out, nil
+}
+
+// GetSecurityDescriptor reads the DACL of an object
+func (c *Client) GetSecurityDescriptor(dn string) (*SecurityDescriptor, error) {
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=*)",
+		[]string{"nTSecurityDescriptor"},
+		[]ldap.Control{NewControlSDFlags(sdFlagsDACL)},
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read security descriptor of %s: %w", dn, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("object not found: %s", dn)
+	}
+
+	raw := result.Entries[0].GetRawAttributeValue("nTSecurityDescriptor")
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("no access to the security descriptor of %s", dn)
+	}
+
+	sd, err := ParseSecurityDescriptor(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse security descriptor of %s: %w", dn, err)
+	}
+
+	return sd, nil
+}
+
+// SetSecurityDescriptor writes the DACL of an object. The owner, group and
+// SACL are left untouched.
+func (c *Client) SetSecurityDescriptor(dn string, sd *SecurityDescriptor) error {
+	// Only the DACL is written, so drop the parts the control excludes
+	daclOnly := &SecurityDescriptor{
+		Revision: sd.Revision,
+		Control:  sd.Control,
+		DACL:     sd.DACL,
+	}
+
+	raw, err := daclOnly.Bytes()
+	if err != nil {
+		return fmt.Errorf("failed to encode security descriptor of %s: %w", dn, err)
+	}
+
+	modifyRequest := ldap.NewModifyRequest(dn, []ldap.Control{NewControlSDFlags(sdFlagsDACL)})
+	modifyRequest.Replace("nTSecurityDescriptor", []string{string(raw)})
+
+	if err := c.Modify(modifyRequest); err != nil {
+		return fmt.Errorf("failed to update security descriptor of %s: %w", dn, err)
+	}
+
+	return nil
+}
+
+// deletionProtectionMask is what "Protect object from accidental deletion"
+// denies Everyone on the object itself
+const deletionProtectionMask = RightDelete | RightDSDeleteTree
+
+// IsProtectedFromDeletion reports whether an object carries the explicit
+// Deny Delete / Delete Subtree ACE for Everyone that ADUC and the AD
+// PowerShell module use to protect objects from accidental deletion
+func (c *Client) IsProtectedFromDeletion(dn string) (bool, error) {
+	sd, err := c.GetSecurityDescriptor(dn)
+	if err != nil {
+		return false, err
+	}
+
+	return sd.DACL != nil && hasDenyACE(sd.DACL, SIDEveryone, deletionProtectionMask), nil
+}
+
+// SetProtectedFromDeletion adds or removes the accidental deletion
+// protection of an object. Protecting also denies Everyone Delete Child on
+// the parent, as ADUC does; that ACE is left in place when unprotecting,
+// since it may protect other children too.
+func (c *Client) SetProtectedFromDeletion(dn string, protect bool) error {
+	c.securityMu.Lock()
+	defer c.securityMu.Unlock()
+
+	sd, err := c.GetSecurityDescriptor(dn)
+	if err != nil {
+		return err
+	}
+	if sd.DACL == nil {
+		sd.DACL = &ACL{Revision: 4}
+	}
+
+	if protect == hasDenyACE(sd.DACL, SIDEveryone, deletionProtectionMask) {
+		return nil
+	}
+
+	if !protect {
+		removeDenyRights(sd.DACL, SIDEveryone, deletionProtectionMask)
+		return c.SetSecurityDescriptor(dn, sd)
+	}
+
+	addDenyACE(sd.DACL, SIDEveryone, deletionProtectionMask)
+	if err := c.SetSecurityDescriptor(dn, sd); err != nil {
+		return err
+	}
+
+	parent, err := ParentDN(dn)
+	if err != nil {
+		return err
+	}
+
+	parentSD, err := c.GetSecurityDescriptor(parent)
+	if err != nil {
+		return err
+	}
+	if parentSD.DACL == nil {
+		parentSD.DACL = &ACL{Revision: 4}
+	}
+
+	if hasDenyACE(parentSD.DACL, SIDEveryone, RightDSDeleteChild) {
+		return nil
+	}
+
+	addDenyACE(parentSD.DACL, SIDEveryone, RightDSDeleteChild)
+	return c.SetSecurityDescriptor(parent, parentSD)
+}
+
+// isDenyACEFor reports whether the ACE is a plain explicit deny ACE for the
+// SID, as written by addDenyACE. hasDenyACE and removeDenyRights share this
+// rule, so protection is only reported when it can also be removed.
+func isDenyACEFor(ace *ACE, sid string) bool {
+	return ace.Type == ACETypeAccessDenied && ace.Flags == 0 && ace.SID == sid
+}
+
+// hasDenyACE reports whether the ACL explicitly denies the SID all of the
+// rights in mask
+func hasDenyACE(acl *ACL, sid string, mask uint32) bool {
+	var denied uint32
+	for _, ace := range acl.ACEs {
+		if isDenyACEFor(ace, sid) {
+			denied |= ace.Mask
+		}
+	}
+
+	return denied&mask == mask
+}
+
+// addDenyACE adds an explicit deny ACE in front of the ACL, where Windows
+// expects explicit deny ACEs in canonical order
+func addDenyACE(acl *ACL, sid string, mask uint32) {
+	ace := &ACE{Type: ACETypeAccessDenied, Mask: mask, SID: sid}
+	acl.ACEs = append([]*ACE{ace}, acl.ACEs...)
+}
+
+// removeDenyRights strips the rights in mask from the explicit deny ACEs of
+// the SID, dropping ACEs that end up empty
+func removeDenyRights(acl *ACL, sid string, mask uint32) {
+	kept := acl.ACEs[:0]
+	for _, ace := range acl.ACEs {
+		if isDenyACEFor(ace, sid) {
+			ace.Mask &^= mask
+			if ace.Mask == 0 {
+				continue
+			}
+		}
+		kept = append(kept, ace)
+	}
+	acl.ACEs = kept
+}
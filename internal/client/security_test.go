@@ -0,0 +1,209 @@
+package client
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestSecurityDescriptorRoundTrip(t *testing.T) {
+	owner, err := ParseSID(SIDBuiltinAdministrators)
+	if err != nil {
+		t.Fatal(err)
+	}
+	group, err := ParseSID("S-1-5-21-1004336348-1177238915-682003330-513")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var aces []*ACE
+	for _, sddl := range []string{
+		"(D;;DTSD;;;S-1-1-0)",
+		"(OA;CIIO;WP;bf9679c0-0de6-11d0-a285-00aa003049e2;bf967a9c-0de6-11d0-a285-00aa003049e2;S-1-5-21-1004336348-1177238915-682003330-1105)",
+		"(A;;CCDCLCSWRPWPDTLOCRSDRCWDWO;;;S-1-5-18)",
+		"(A;CIID;LCRPLORC;;;S-1-5-11)",
+	} {
+		ace, err := ParseACESDDL(sddl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		aces = append(aces, ace)
+	}
+
+	// An ACE type without SDDL support is kept as raw bytes
+	callback := []byte{0x09, 0, 16, 0, 0x10, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 1}
+	aces = append(aces, &ACE{Type: callback[0], raw: callback})
+
+	// An empty SACL, kept as raw bytes
+	sacl := []byte{4, 0, 8, 0, 0, 0, 0, 0}
+
+	tests := map[string]*SecurityDescriptor{
+		"full": {
+			Revision: 1,
+			Control:  securityDescriptorControlSelfRelative,
+			Owner:    owner,
+			Group:    group,
+			SACL:     sacl,
+			DACL:     &ACL{Revision: 4, ACEs: aces},
+		},
+		"DACL only": {
+			Revision: 1,
+			Control:  securityDescriptorControlSelfRelative,
+			DACL:     &ACL{Revision: 2, ACEs: aces[2:3]},
+		},
+		"empty DACL": {
+			Revision: 1,
+			Control:  securityDescriptorControlSelfRelative,
+			Owner:    owner,
+			DACL:     &ACL{Revision: 2},
+		},
+		"no DACL": {
+			Revision: 1,
+			Control:  securityDescriptorControlSelfRelative,
+			Owner:    owner,
+			Group:    group,
+		},
+	}
+
+	for name, sd := range tests {
+		t.Run(name, func(t *testing.T) {
+			raw, err := sd.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes returned error: %s", err)
+			}
+
+			parsed, err := ParseSecurityDescriptor(raw)
+			if err != nil {
+				t.Fatalf("ParseSecurityDescriptor returned error: %s", err)
+			}
+
+			if !bytes.Equal(parsed.Owner, sd.Owner) || !bytes.Equal(parsed.Group, sd.Group) || !bytes.Equal(parsed.SACL, sd.SACL) {
+				t.Errorf("owner, group or SACL changed: %x %x %x", parsed.Owner, parsed.Group, parsed.SACL)
+			}
+			if (parsed.DACL == nil) != (sd.DACL == nil) {
+				t.Fatalf("DACL = %+v, want %+v", parsed.DACL, sd.DACL)
+			}
+			if sd.DACL != nil {
+				if len(parsed.DACL.ACEs) != len(sd.DACL.ACEs) {
+					t.Fatalf("DACL has %d ACEs, want %d", len(parsed.DACL.ACEs), len(sd.DACL.ACEs))
+				}
+				for i, ace := range sd.DACL.ACEs {
+					parsedACE := parsed.DACL.ACEs[i]
+					if ace.raw != nil {
+						if !bytes.Equal(parsedACE.raw, ace.raw) {
+							t.Errorf("raw ACE %d = %x, want %x", i, parsedACE.raw, ace.raw)
+						}
+						continue
+					}
+					if !parsedACE.Equal(ace) {
+						t.Errorf("ACE %d = %s, want %s", i, parsedACE.SDDL(), ace.SDDL())
+					}
+				}
+			}
+
+			// Encoding the parsed descriptor again gives the same bytes
+			again, err := parsed.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes of the parsed descriptor returned error: %s", err)
+			}
+			if !bytes.Equal(again, raw) {
+				t.Errorf("second encoding differs:\n%x\n%x", again, raw)
+			}
+		})
+	}
+}
+
+func TestParseSecurityDescriptorMalformed(t *testing.T) {
+	// A header pointing at offset 255 for every part, with nothing behind it
+	header := func(owner, group, sacl, dacl uint32) []byte {
+		raw := make([]byte, 20)
+		raw[0] = 1
+		binary.LittleEndian.PutUint32(raw[4:8], owner)
+		binary.LittleEndian.PutUint32(raw[8:12], group)
+		binary.LittleEndian.PutUint32(raw[12:16], sacl)
+		binary.LittleEndian.PutUint32(raw[16:20], dacl)
+		return raw
+	}
+
+	// An ACL header claiming a size and ACE count it doesn't have
+	acl := func(size, count uint16) []byte {
+		raw := header(0, 0, 0, 20)
+		raw = append(raw, 4, 0, 0, 0, 0, 0, 0, 0)
+		binary.LittleEndian.PutUint16(raw[22:24], size)
+		binary.LittleEndian.PutUint16(raw[24:26], count)
+		return raw
+	}
+
+	tests := map[string][]byte{
+		"empty":                  nil,
+		"short header":           make([]byte, 19),
+		"owner out of range":     header(255, 0, 0, 0),
+		"group out of range":     header(0, 255, 0, 0),
+		"SACL out of range":      header(0, 0, 255, 0),
+		"DACL out of range":      header(0, 0, 0, 255),
+		"offset overflow":        header(0, 0, 0, 0xffffffff),
+		"owner size":             append(header(20, 0, 0, 0), 1, 15, 0, 0, 0, 0, 0, 5),
+		"ACL size too small":     acl(4, 0),
+		"ACL size out of range":  acl(64, 0),
+		"ACE count out of range": acl(8, 1),
+		"ACE size out of range": append(acl(16, 1),
+			ACETypeAccessDenied, 0, 200, 0, 0, 0, 0, 0),
+		"ACE too short": append(acl(12, 1),
+			ACETypeAccessDenied, 0, 4, 0),
+		"ACE without SID": append(acl(16, 1),
+			ACETypeAccessDenied, 0, 8, 0, 0, 0, 1, 0),
+		"object ACE GUID missing": append(acl(20, 1),
+			ACETypeAccessAllowedObject, 0, 12, 0, 0, 0, 1, 0, aceObjectTypePresent, 0, 0, 0),
+	}
+
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("ParseSecurityDescriptor panicked: %v", r)
+				}
+			}()
+
+			if _, err := ParseSecurityDescriptor(raw); err == nil {
+				t.Errorf("ParseSecurityDescriptor(%x) returned no error", raw)
+			}
+		})
+	}
+}
+
+func TestDenyACEMatching(t *testing.T) {
+	const mask = RightDelete | RightDSDeleteTree
+
+	tests := []struct {
+		name      string
+		ace       *ACE
+		protected bool
+		kept      int
+	}{
+		{"explicit deny", &ACE{Type: ACETypeAccessDenied, Mask: mask, SID: SIDEveryone}, true, 0},
+		{"deny with other rights", &ACE{Type: ACETypeAccessDenied, Mask: mask | RightWriteDAC, SID: SIDEveryone}, true, 1},
+		{"partial deny", &ACE{Type: ACETypeAccessDenied, Mask: RightDelete, SID: SIDEveryone}, false, 0},
+		{"inherited deny", &ACE{Type: ACETypeAccessDenied, Flags: ACEFlagInherited, Mask: mask, SID: SIDEveryone}, false, 1},
+		{"inheritable deny", &ACE{Type: ACETypeAccessDenied, Flags: ACEFlagContainerInherit, Mask: mask, SID: SIDEveryone}, false, 1},
+		{"other SID", &ACE{Type: ACETypeAccessDenied, Mask: mask, SID: "S-1-5-11"}, false, 1},
+		{"allow", &ACE{Type: ACETypeAccessAllowed, Mask: mask, SID: SIDEveryone}, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acl := &ACL{Revision: 4, ACEs: []*ACE{tt.ace}}
+			if protected := hasDenyACE(acl, SIDEveryone, mask); protected != tt.protected {
+				t.Fatalf("hasDenyACE = %t, want %t", protected, tt.protected)
+			}
+
+			// Whatever hasDenyACE reports as protection must be removable
+			removeDenyRights(acl, SIDEveryone, mask)
+			if hasDenyACE(acl, SIDEveryone, mask) {
+				t.Errorf("hasDenyACE still reports protection after removeDenyRights")
+			}
+			if len(acl.ACEs) != tt.kept {
+				t.Errorf("removeDenyRights kept %d ACEs, want %d", len(acl.ACEs), tt.kept)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// windowsEpochOffset is the number of 100-nanosecond intervals between the
+// Windows FILETIME epoch (1601-01-01) and the Unix epoch (1970-01-01)
+const windowsEpochOffset = 116444736000000000
+
+// FormatGeneralizedTime converts an LDAP GeneralizedTime value such as
+// "20230615103000.0Z" to RFC 3339. Unparseable values are returned as-is.
+func FormatGeneralizedTime(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	// Active Directory always returns a ".0Z" suffix; ignore the fraction
+	trimmed := value
+	if idx := strings.IndexAny(trimmed, ".Z"); idx >= 0 {
+		trimmed = trimmed[:idx]
+	}
+
+	t, err := time.Parse("20060102150405", trimmed)
+	if err != nil {
+		return value
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+// FormatFileTime converts a Windows FILETIME (Integer8) attribute such as
+// lastLogonTimestamp to RFC 3339. Zero and "never" values yield "".
+func FormatFileTime(value string) string {
+	ticks, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ticks <= 0 || ticks == 1<<63-1 {
+		return ""
+	}
+
+	unixTicks := ticks - windowsEpochOffset
+	return time.Unix(unixTicks/10000000, (unixTicks%10000000)*100).UTC().Format(time.RFC3339)
+}
+
+// intervalNever is the Integer8 interval value meaning "never" (or, for
+// lockout durations, "until an administrator unlocks the account")
+const intervalNever = -1 << 63
+
+// FormatInterval converts a duration to a negative Integer8 interval in
+// 100-nanosecond units, as used by password and lockout settings. With
+// zeroIsNever set, a zero duration is encoded as "never".
+func FormatInterval(d time.Duration, zeroIsNever bool) string {
+	if d == 0 && zeroIsNever {
+		return strconv.FormatInt(intervalNever, 10)
+	}
+	return strconv.FormatInt(-int64(d/100), 10)
+}
+
+// ParseInterval converts a negative Integer8 interval to a duration, the
+// inverse of FormatInterval. "Never" and unparseable values yield zero.
+func ParseInterval(value string) time.Duration {
+	ticks, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ticks == intervalNever {
+		return 0
+	}
+	if ticks < 0 {
+		ticks = -ticks
+	}
+	return time.Duration(ticks) * 100
+}
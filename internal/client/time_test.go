@@ -0,0 +1,59 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatInterval(t *testing.T) {
+	tests := []struct {
+		duration    time.Duration
+		zeroIsNever bool
+		value       string
+	}{
+		{0, false, "0"},
+		{0, true, "-9223372036854775808"},
+		{time.Minute, false, "-600000000"},
+		{30 * time.Minute, true, "-18000000000"},
+		{24 * time.Hour, false, "-864000000000"},
+		{42 * 24 * time.Hour, true, "-36288000000000"},
+	}
+
+	for _, tt := range tests {
+		if value := FormatInterval(tt.duration, tt.zeroIsNever); value != tt.value {
+			t.Errorf("FormatInterval(%s, %t) = %s, want %s", tt.duration, tt.zeroIsNever, value, tt.value)
+		}
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		value    string
+		duration time.Duration
+	}{
+		{"0", 0},
+		{"-9223372036854775808", 0},
+		{"-600000000", time.Minute},
+		{"-864000000000", 24 * time.Hour},
+		// Some tools write positive values
+		{"864000000000", 24 * time.Hour},
+		{"", 0},
+		{"never", 0},
+	}
+
+	for _, tt := range tests {
+		if duration := ParseInterval(tt.value); duration != tt.duration {
+			t.Errorf("ParseInterval(%q) = %s, want %s", tt.value, duration, tt.duration)
+		}
+	}
+}
+
+func TestIntervalRoundTrip(t *testing.T) {
+	for _, duration := range []time.Duration{0, time.Minute, 30 * time.Minute, 24 * time.Hour, 999 * 24 * time.Hour} {
+		for _, zeroIsNever := range []bool{false, true} {
+			if parsed := ParseInterval(FormatInterval(duration, zeroIsNever)); parsed != duration {
+				t.Errorf("ParseInterval(FormatInterval(%s, %t)) = %s", duration, zeroIsNever, parsed)
+			}
+		}
+	}
+}
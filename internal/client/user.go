@@ -1,24 +1,99 @@
 package client
 
 import (
+	"encoding/binary"
 	"fmt"
+	"strconv"
+	"unicode/utf16"
 
 	"github.com/go-ldap/ldap/v3"
 )
 
+// userAccountControl flags
+const (
+	UACAccountDisable          = 0x0002
+	UACPasswordNotRequired     = 0x0020
+	UACNormalAccount           = 0x0200
+	UACWorkstationTrustAccount = 0x1000
+	UACDontExpirePassword      = 0x10000
+)
+
+// userFilter matches user accounts while excluding computer objects, which
+// also carry objectClass=user
+const userFilter = "(&(objectCategory=person)(objectClass=user))"
+
 // User represents an Active Directory user
 type User struct {
-	DN                string   `json:"dn"`
-	CN                string   `json:"cn"`
-	SamAccountName    string   `json:"sam_account_name"`
-	UserPrincipalName string   `json:"user_principal_name"`
-	DisplayName       string   `json:"display_name"`
-	GivenName         string   `json:"given_name"`
-	Surname           string   `json:"surname"`
-	Email             string   `json:"email"`
-	MemberOf          []string `json:"member_of"`
-	ObjectGUID        string   `json:"object_guid"`
-	ObjectSid         string   `json:"object_sid"`
+	DN                   string   `json:"dn"`
+	CN                   string   `json:"cn"`
+	SamAccountName       string   `json:"sam_account_name"`
+	UserPrincipalName    string   `json:"user_principal_name"`
+	DisplayName          string   `json:"display_name"`
+	GivenName            string   `json:"given_name"`
+	Surname              string   `json:"surname"`
+	Email                string   `json:"email"`
+	Enabled              bool     `json:"enabled"`
+	PasswordNeverExpires bool     `json:"password_never_expires"`
+	Description          string   `json:"description"`
+	Manager              string   `json:"manager"`
+	Department           string   `json:"department"`
+	Title                string   `json:"title"`
+	EmployeeID           string   `json:"employee_id"`
+	WhenCreated          string   `json:"when_created"`
+	LastLogonTimestamp   string   `json:"last_logon_timestamp"`
+	MemberOf             []string `json:"member_of"`
+	ObjectGUID           string   `json:"object_guid"`
+	ObjectSid            string   `json:"object_sid"`
+}
+
+// userAttributes lists the attributes fetched for every user lookup
+var userAttributes = []string{
+	"cn",
+	"sAMAccountName",
+	"userPrincipalName",
+	"displayName",
+	"givenName",
+	"sn",
+	"mail",
+	"userAccountControl",
+	"description",
+	"manager",
+	"department",
+	"title",
+	"employeeID",
+	"whenCreated",
+	"lastLogonTimestamp",
+	"memberOf",
+	"objectGUID",
+	"objectSid",
+}
+
+// userFromEntry maps a search result entry to a User
+func userFromEntry(entry *ldap.Entry) *User {
+	uac, _ := strconv.ParseInt(entry.GetAttributeValue("userAccountControl"), 10, 64)
+
+	return &User{
+		DN:                   entry.DN,
+		CN:                   entry.GetAttributeValue("cn"),
+		SamAccountName:       entry.GetAttributeValue("sAMAccountName"),
+		UserPrincipalName:    entry.GetAttributeValue("userPrincipalName"),
+		DisplayName:          entry.GetAttributeValue("displayName"),
+		GivenName:            entry.GetAttributeValue("givenName"),
+		Surname:              entry.GetAttributeValue("sn"),
+		Email:                entry.GetAttributeValue("mail"),
+		Enabled:              uac&UACAccountDisable == 0,
+		PasswordNeverExpires: uac&UACDontExpirePassword != 0,
+		Description:          entry.GetAttributeValue("description"),
+		Manager:              entry.GetAttributeValue("manager"),
+		Department:           entry.GetAttributeValue("department"),
+		Title:                entry.GetAttributeValue("title"),
+		EmployeeID:           entry.GetAttributeValue("employeeID"),
+		WhenCreated:          FormatGeneralizedTime(entry.GetAttributeValue("whenCreated")),
+		LastLogonTimestamp:   FormatFileTime(entry.GetAttributeValue("lastLogonTimestamp")),
+		MemberOf:             entry.GetAttributeValues("memberOf"),
+		ObjectGUID:           FormatGUID(entry.GetRawAttributeValue("objectGUID")),
+		ObjectSid:            FormatSID(entry.GetRawAttributeValue("objectSid")),
+	}
 }
 
 // GetUser retrieves a user by their distinguished name
@@ -30,19 +105,8 @@ func (c *Client) GetUser(dn string) (*User, error) {
 		0,
 		0,
 		false,
-		"(objectClass=user)",
-		[]string{
-			"cn",
-			"sAMAccountName",
-			"userPrincipalName",
-			"displayName",
-			"givenName",
-			"sn",
-			"mail",
-			"memberOf",
-			"objectGUID",
-			"objectSid",
-		},
+		userFilter,
+		userAttributes,
 		nil,
 	)
 
@@ -55,19 +119,14 @@ func (c *Client) GetUser(dn string) (*User, error) {
 		return nil, fmt.Errorf("user not found: %s", dn)
 	}
 
-	entry := result.Entries[0]
-	user := &User{
-		DN:                entry.DN,
-		CN:                entry.GetAttributeValue("cn"),
-		SamAccountName:    entry.GetAttributeValue("sAMAccountName"),
-		UserPrincipalName: entry.GetAttributeValue("userPrincipalName"),
-		DisplayName:       entry.GetAttributeValue("displayName"),
-		GivenName:         entry.GetAttributeValue("givenName"),
-		Surname:           entry.GetAttributeValue("sn"),
-		Email:             entry.GetAttributeValue("mail"),
-		MemberOf:          entry.GetAttributeValues("memberOf"),
-		ObjectGUID:        entry.GetAttributeValue("objectGUID"),
-		ObjectSid:         entry.GetAttributeValue("objectSid"),
+	return userFromEntry(result.Entries[0]), nil
+}
+
+// GetUserByGUID retrieves a user by their objectGUID
+func (c *Client) GetUserByGUID(guid string) (*User, error) {
+	user, err := c.GetUser(GUIDReference(guid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user with GUID %s: %w", guid, err)
 	}
 
 	return user, nil
@@ -75,8 +134,24 @@ func (c *Client) GetUser(dn string) (*User, error) {
 
 // GetUserBySAM retrieves a user by their SAM account name
 func (c *Client) GetUserBySAM(samAccountName string) (*User, error) {
-	filter := fmt.Sprintf("(&(objectClass=user)(sAMAccountName=%s))", EscapeFilter(samAccountName))
-	
+	return c.findUser("sAMAccountName", samAccountName)
+}
+
+// GetUserByUPN retrieves a user by their user principal name
+func (c *Client) GetUserByUPN(userPrincipalName string) (*User, error) {
+	return c.findUser("userPrincipalName", userPrincipalName)
+}
+
+// GetUserByEmail retrieves a user by their mail attribute
+func (c *Client) GetUserByEmail(email string) (*User, error) {
+	return c.findUser("mail", email)
+}
+
+// findUser searches the directory for exactly one user whose attribute
+// matches the given value
+func (c *Client) findUser(attribute, value string) (*User, error) {
+	filter := fmt.Sprintf("(&%s(%s=%s))", userFilter, attribute, EscapeFilter(value))
+
 	searchRequest := ldap.NewSearchRequest(
 		c.baseDN,
 		ldap.ScopeWholeSubtree,
@@ -85,44 +160,167 @@ func (c *Client) GetUserBySAM(samAccountName string) (*User, error) {
 		0,
 		false,
 		filter,
-		[]string{
-			"cn",
-			"sAMAccountName",
-			"userPrincipalName",
-			"displayName",
-			"givenName",
-			"sn",
-			"mail",
-			"memberOf",
-			"objectGUID",
-			"objectSid",
-		},
+		userAttributes,
 		nil,
 	)
 
 	result, err := c.Search(searchRequest)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search for user with SAM %s: %w", samAccountName, err)
+		return nil, fmt.Errorf("failed to search for user with %s %s: %w", attribute, value, err)
 	}
 
 	if len(result.Entries) == 0 {
-		return nil, fmt.Errorf("user not found with SAM: %s", samAccountName)
+		return nil, fmt.Errorf("user not found with %s: %s", attribute, value)
 	}
 
-	entry := result.Entries[0]
-	user := &User{
-		DN:                entry.DN,
-		CN:                entry.GetAttributeValue("cn"),
-		SamAccountName:    entry.GetAttributeValue("sAMAccountName"),
-		UserPrincipalName: entry.GetAttributeValue("userPrincipalName"),
-		DisplayName:       entry.GetAttributeValue("displayName"),
-		GivenName:         entry.GetAttributeValue("givenName"),
-		Surname:           entry.GetAttributeValue("sn"),
-		Email:             entry.GetAttributeValue("mail"),
-		MemberOf:          entry.GetAttributeValues("memberOf"),
-		ObjectGUID:        entry.GetAttributeValue("objectGUID"),
-		ObjectSid:         entry.GetAttributeValue("objectSid"),
+	if len(result.Entries) > 1 {
+		return nil, &AmbiguousResultError{
+			ObjectType: "user",
+			Attribute:  attribute,
+			Value:      value,
+			DNs:        entryDNs(result.Entries),
+		}
 	}
 
-	return user, nil
+	return userFromEntry(result.Entries[0]), nil
+}
+
+// ListUsers lists the users below searchBase that match the given filter.
+// The filter is combined with the user object filter; an empty searchBase
+// defaults to the configured base DN.
+func (c *Client) ListUsers(searchBase string, scope int, filter string) ([]*User, error) {
+	if searchBase == "" {
+		searchBase = c.baseDN
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		searchBase,
+		scope,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&%s%s)", userFilter, filter),
+		userAttributes,
+		nil,
+	)
+
+	result, err := c.SearchPaged(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users := make([]*User, len(result.Entries))
+	for i, entry := range result.Entries {
+		users[i] = userFromEntry(entry)
+	}
+
+	return users, nil
+}
+
+// CreateUser creates a new user in a single add operation and returns its
+// DN. The userAccountControl value is set as given. If password is not
+// empty, it is set through unicodePwd, which requires an encrypted
+// connection.
+func (c *Client) CreateUser(ou, cn string, userAccountControl int, password string, attributes map[string][]string) (string, error) {
+	dn := fmt.Sprintf("CN=%s,%s", EscapeDN(cn), ou)
+
+	required := map[string][]string{
+		"cn":                 {cn},
+		"userAccountControl": {strconv.Itoa(userAccountControl)},
+	}
+
+	if password != "" {
+		if !c.useTLS {
+			return "", fmt.Errorf("setting a password requires an encrypted connection; enable use_tls")
+		}
+		required["unicodePwd"] = []string{EncodePassword(password)}
+	}
+
+	err := c.addEntry(dn, []string{"top", "person", "organizationalPerson", "user"}, entryAttributes(attributes, required))
+	if err != nil {
+		return "", fmt.Errorf("failed to create user %s: %w", dn, err)
+	}
+
+	return dn, nil
+}
+
+// UpdateUser updates an existing user
+func (c *Client) UpdateUser(dn string, updates map[string][]string) error {
+	err := c.modifyAttributes(dn, updates)
+	if err != nil {
+		return fmt.Errorf("failed to update user %s: %w", dn, err)
+	}
+
+	return nil
+}
+
+// SetUserAccountControl sets and clears userAccountControl flags of an
+// account, leaving the other flags as they are
+func (c *Client) SetUserAccountControl(dn string, set, clear int) error {
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=user)",
+		[]string{"userAccountControl"},
+		nil,
+	)
+
+	result, err := c.Search(searchRequest)
+	if err != nil {
+		return fmt.Errorf("failed to read userAccountControl of %s: %w", dn, err)
+	}
+
+	if len(result.Entries) == 0 {
+		return fmt.Errorf("account not found: %s", dn)
+	}
+
+	current, err := strconv.Atoi(result.Entries[0].GetAttributeValue("userAccountControl"))
+	if err != nil {
+		return fmt.Errorf("invalid userAccountControl on %s: %w", dn, err)
+	}
+
+	updated := current&^clear | set
+	if updated == current {
+		return nil
+	}
+
+	// Replace through a delete and add of the old value, so a concurrent
+	// change makes this fail instead of being overwritten
+	modifyRequest := ldap.NewModifyRequest(dn, nil)
+	modifyRequest.Delete("userAccountControl", []string{strconv.Itoa(current)})
+	modifyRequest.Add("userAccountControl", []string{strconv.Itoa(updated)})
+
+	if err := c.Modify(modifyRequest); err != nil {
+		return fmt.Errorf("failed to update userAccountControl of %s: %w", dn, err)
+	}
+
+	return nil
+}
+
+// DeleteUser deletes a user
+func (c *Client) DeleteUser(dn string) error {
+	err := c.Delete(ldap.NewDelRequest(dn, nil))
+	if err != nil {
+		return fmt.Errorf("failed to delete user %s: %w", dn, err)
+	}
+
+	return nil
+}
+
+// EncodePassword encodes a password for unicodePwd: the quoted password in
+// UTF-16LE
+func EncodePassword(password string) string {
+	encoded := utf16.Encode([]rune("\"" + password + "\""))
+
+	raw := make([]byte, 0, 2*len(encoded))
+	for _, unit := range encoded {
+		raw = binary.LittleEndian.AppendUint16(raw, unit)
+	}
+
+	return string(raw)
 }
@@ -0,0 +1,23 @@
+package client
+
+import "testing"
+
+func TestEncodePassword(t *testing.T) {
+	tests := []struct {
+		password string
+		encoded  string
+	}{
+		{"", "\"\x00\"\x00"},
+		{"Pa1", "\"\x00P\x00a\x001\x00\"\x00"},
+		{"é", "\"\x00\xe9\x00\"\x00"},
+		{"€", "\"\x00\xac\x20\"\x00"},
+		// Characters outside the BMP take a surrogate pair
+		{"😀", "\"\x00\x3d\xd8\x00\xde\"\x00"},
+	}
+
+	for _, tt := range tests {
+		if encoded := EncodePassword(tt.password); encoded != tt.encoded {
+			t.Errorf("EncodePassword(%q) = %x, want %x", tt.password, encoded, tt.encoded)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ComputerDataSource{}
+
+func NewComputerDataSource() datasource.DataSource {
+	return &ComputerDataSource{}
+}
+
+// ComputerDataSource defines the data source implementation.
+type ComputerDataSource struct {
+	client *client.Client
+}
+
+// ComputerDataSourceModel describes the data source data model.
+type ComputerDataSourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	DN              types.String   `tfsdk:"dn"`
+	Name            types.String   `tfsdk:"name"`
+	SamAccountName  types.String   `tfsdk:"sam_account_name"`
+	DNSHostName     types.String   `tfsdk:"dns_host_name"`
+	Description     types.String   `tfsdk:"description"`
+	ManagedBy       types.String   `tfsdk:"managed_by"`
+	Enabled         types.Bool     `tfsdk:"enabled"`
+	OperatingSystem types.String   `tfsdk:"operating_system"`
+	WhenCreated     types.String   `tfsdk:"when_created"`
+	MemberOf        []types.String `tfsdk:"member_of"`
+	ObjectGUID      types.String   `tfsdk:"object_guid"`
+	ObjectSid       types.String   `tfsdk:"object_sid"`
+}
+
+func (d *ComputerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_computer"
+}
+
+func (d *ComputerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches information about an Active Directory computer account.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the computer (same as DN).",
+			},
+			"dn": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Distinguished Name of the computer. Exactly one of 'dn', 'sam_account_name', 'dns_host_name' or 'object_guid' must be specified.",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name (cn) of the computer.",
+			},
+			"sam_account_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "SAM account name of the computer. Can be used to look up the computer, with or without the trailing '$'.",
+			},
+			"dns_host_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "DNS host name of the computer. Can be used to look up the computer.",
+			},
+			"description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Description of the computer.",
+			},
+			"managed_by": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Distinguished Name of the user or group managing the computer.",
+			},
+			"enabled": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the account is enabled, derived from userAccountControl.",
+			},
+			"operating_system": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Operating system reported by the computer. Empty for pre-staged accounts.",
+			},
+			"when_created": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time the account was created, in RFC 3339 format.",
+			},
+			"member_of": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "List of Distinguished Names of groups this computer is a member of.",
+			},
+			"object_guid": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the computer. Can be used to look up the computer.",
+			},
+			"object_sid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectSid of the computer.",
+			},
+		},
+	}
+}
+
+func (d *ComputerDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ComputerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ComputerDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var lookups []string
+	for name, value := range map[string]types.String{
+		"dn":               data.DN,
+		"sam_account_name": data.SamAccountName,
+		"dns_host_name":    data.DNSHostName,
+		"object_guid":      data.ObjectGUID,
+	} {
+		if !value.IsNull() && !value.IsUnknown() {
+			lookups = append(lookups, name)
+		}
+	}
+
+	if len(lookups) != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid Lookup Attributes",
+			"Exactly one of 'dn', 'sam_account_name', 'dns_host_name' or 'object_guid' must be specified to look up the computer.",
+		)
+		return
+	}
+
+	var computer *client.Computer
+	var err error
+
+	switch lookups[0] {
+	case "dn":
+		computer, err = d.client.GetComputer(data.DN.ValueString())
+	case "sam_account_name":
+		computer, err = d.client.GetComputerBySAM(data.SamAccountName.ValueString())
+	case "dns_host_name":
+		computer, err = d.client.GetComputerByDNSHostName(data.DNSHostName.ValueString())
+	case "object_guid":
+		computer, err = d.client.GetComputerByGUID(data.ObjectGUID.ValueString())
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read computer, got error: %s", err))
+		return
+	}
+
+	// Map response to the data model
+	data.ID = types.StringValue(computer.DN)
+	data.DN = types.StringValue(computer.DN)
+	data.Name = types.StringValue(computer.CN)
+	if lookups[0] != "sam_account_name" {
+		// Keep a configured name as written, with or without the "$"
+		data.SamAccountName = types.StringValue(computer.SamAccountName)
+	}
+	data.DNSHostName = types.StringValue(computer.DNSHostName)
+	data.Description = types.StringValue(computer.Description)
+	data.ManagedBy = types.StringValue(computer.ManagedBy)
+	data.Enabled = types.BoolValue(computer.Enabled)
+	data.OperatingSystem = types.StringValue(computer.OperatingSystem)
+	data.WhenCreated = types.StringValue(computer.WhenCreated)
+	data.ObjectGUID = types.StringValue(computer.ObjectGUID)
+	data.ObjectSid = types.StringValue(computer.ObjectSid)
+
+	// Convert memberOf slice
+	memberOf := make([]types.String, len(computer.MemberOf))
+	for i, group := range computer.MemberOf {
+		memberOf[i] = types.StringValue(group)
+	}
+	data.MemberOf = memberOf
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -2,8 +2,9 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -25,18 +26,23 @@ type GroupDataSource struct {
 
 // GroupDataSourceModel describes the data source data model.
 type GroupDataSourceModel struct {
-	ID             types.String   `tfsdk:"id"`
-	DN             types.String   `tfsdk:"dn"`
-	CN             types.String   `tfsdk:"cn"`
-	Name           types.String   `tfsdk:"name"`
-	SamAccountName types.String   `tfsdk:"sam_account_name"`
-	Description    types.String   `tfsdk:"description"`
-	GroupType      types.Int64    `tfsdk:"group_type"`
-	ManagedBy      types.String   `tfsdk:"managed_by"`
-	Members        []types.String `tfsdk:"members"`
-	MemberOf       []types.String `tfsdk:"member_of"`
-	ObjectGUID     types.String   `tfsdk:"object_guid"`
-	ObjectSid      types.String   `tfsdk:"object_sid"`
+	ID             types.String     `tfsdk:"id"`
+	SearchBase     types.String     `tfsdk:"search_base"`
+	DN             types.String     `tfsdk:"dn"`
+	CN             types.String     `tfsdk:"cn"`
+	Name           types.String     `tfsdk:"name"`
+	SamAccountName types.String     `tfsdk:"sam_account_name"`
+	Description    types.String     `tfsdk:"description"`
+	Mail           types.String     `tfsdk:"mail"`
+	Email          *GroupEmailModel `tfsdk:"email"`
+	GroupType      types.Int64      `tfsdk:"group_type"`
+	Scope          types.String     `tfsdk:"scope"`
+	Category       types.String     `tfsdk:"category"`
+	ManagedBy      types.String     `tfsdk:"managed_by"`
+	Members        []types.String   `tfsdk:"members"`
+	MemberOf       []types.String   `tfsdk:"member_of"`
+	ObjectGUID     types.String     `tfsdk:"object_guid"`
+	ObjectSid      types.String     `tfsdk:"object_sid"`
 }
 
 func (d *GroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -51,31 +57,50 @@ func (d *GroupDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:            true,
 				MarkdownDescription: "Unique identifier for the group (same as DN).",
 			},
+			"search_base": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Distinguished Name to search below when looking up by 'cn', 'sam_account_name' or 'mail'. Defaults to the provider's base DN.",
+			},
 			"dn": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Distinguished Name of the group. Either 'dn' or 'cn' must be specified.",
+				MarkdownDescription: "Distinguished Name of the group. Exactly one of 'dn', 'cn', 'sam_account_name', 'mail', 'object_guid' or 'object_sid' must be specified.",
 			},
 			"cn": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Common Name of the group. Either 'dn' or 'cn' must be specified.",
+				MarkdownDescription: "Common Name of the group. Common names are only unique within an OU, so this lookup fails if more than one group matches.",
 			},
 			"name": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Display name of the group.",
 			},
 			"sam_account_name": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Security Account Manager (SAM) account name.",
+				MarkdownDescription: "Security Account Manager (SAM) account name. Unique per domain, so this is the preferred lookup by name.",
 			},
 			"description": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Description of the group.",
 			},
+			"mail": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Email address of the group. Can be used to look up the group.",
+			},
+			"email": groupEmailDataSourceAttribute(),
 			"group_type": schema.Int64Attribute{
 				Computed:            true,
-				MarkdownDescription: "Group type value.",
+				MarkdownDescription: "Raw groupType bitmask.",
+			},
+			"scope": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Group scope: 'global', 'domain_local' or 'universal'.",
+			},
+			"category": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Group category: 'security' or 'distribution'.",
 			},
 			"managed_by": schema.StringAttribute{
 				Computed:            true,
@@ -92,12 +117,65 @@ func (d *GroupDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				MarkdownDescription: "List of Distinguished Names of groups this group is a member of.",
 			},
 			"object_guid": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "The objectGUID of the group.",
+				MarkdownDescription: "The objectGUID of the group. Can be used to look up the group.",
 			},
 			"object_sid": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "The objectSid of the group.",
+				MarkdownDescription: "The objectSid of the group (e.g. 'S-1-5-21-...-512'). Can be used to look up the group.",
+			},
+		},
+	}
+}
+
+// groupEmailDataSourceAttribute returns the schema of the computed email
+// object shared by the group data sources.
+func groupEmailDataSourceAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "Mail settings of the group. Null if the group has no mail address.",
+		Attributes: map[string]schema.Attribute{
+			"address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Primary SMTP address (mail).",
+			},
+			"alias": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Exchange alias (mailNickname).",
+			},
+			"display_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Display name shown in address lists.",
+			},
+			"proxy_addresses": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Secondary proxy addresses including their type prefix.",
+			},
+			"hidden_from_address_lists": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the group is hidden from address lists.",
+			},
+			"accept_messages_only_from": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Distinguished Names of senders allowed to send to the group (authOrig).",
+			},
+			"accept_messages_only_from_members_of": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Distinguished Names of groups whose members may send to the group (dLMemSubmitPerms).",
+			},
+			"moderation_enabled": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether messages to the group are moderated.",
+			},
+			"moderated_by": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Distinguished Names of the moderators (msExchModeratedByLink).",
 			},
 		},
 	}
@@ -133,18 +211,53 @@ func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	var lookups []string
+	for name, value := range map[string]types.String{
+		"dn":               data.DN,
+		"cn":               data.CN,
+		"sam_account_name": data.SamAccountName,
+		"mail":             data.Mail,
+		"object_guid":      data.ObjectGUID,
+		"object_sid":       data.ObjectSid,
+	} {
+		if !value.IsNull() && !value.IsUnknown() {
+			lookups = append(lookups, name)
+		}
+	}
+
+	if len(lookups) != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid Lookup Attributes",
+			"Exactly one of 'dn', 'cn', 'sam_account_name', 'mail', 'object_guid' or 'object_sid' must be specified to look up the group.",
+		)
+		return
+	}
+
 	var group *client.Group
 	var err error
 
-	// Either DN or CN must be specified
-	if !data.DN.IsNull() && !data.DN.IsUnknown() {
+	searchBase := data.SearchBase.ValueString()
+	switch lookups[0] {
+	case "dn":
 		group, err = d.client.GetGroup(data.DN.ValueString())
-	} else if !data.CN.IsNull() && !data.CN.IsUnknown() {
-		group, err = d.client.GetGroupByCN(data.CN.ValueString())
-	} else {
+	case "cn":
+		group, err = d.client.FindGroup(searchBase, "cn", data.CN.ValueString())
+	case "sam_account_name":
+		group, err = d.client.FindGroup(searchBase, "sAMAccountName", data.SamAccountName.ValueString())
+	case "mail":
+		group, err = d.client.FindGroup(searchBase, "mail", data.Mail.ValueString())
+	case "object_guid":
+		group, err = d.client.GetGroupByGUID(data.ObjectGUID.ValueString())
+	case "object_sid":
+		group, err = d.client.GetGroupBySID(data.ObjectSid.ValueString())
+	}
+
+	var ambiguous *client.AmbiguousResultError
+	if errors.As(err, &ambiguous) {
 		resp.Diagnostics.AddError(
-			"Missing Required Attribute",
-			"Either 'dn' or 'cn' must be specified to look up the group.",
+			"Ambiguous Group Lookup",
+			fmt.Sprintf("More than one group matches %s %q. Narrow 'search_base' or look the group up by 'dn', 'sam_account_name' or 'object_guid' instead.\n\nCandidates:\n  - %s",
+				ambiguous.Attribute, ambiguous.Value, strings.Join(ambiguous.DNs, "\n  - ")),
 		)
 		return
 	}
@@ -161,17 +274,19 @@ func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	data.Name = types.StringValue(group.Name)
 	data.SamAccountName = types.StringValue(group.SamAccountName)
 	data.Description = types.StringValue(group.Description)
-	
-	if group.GroupType != "" {
-		if groupTypeInt, err := strconv.ParseInt(group.GroupType, 10, 64); err == nil {
-			data.GroupType = types.Int64Value(groupTypeInt)
-		}
-	}
-	
+	data.Mail = types.StringValue(group.Mail)
+	data.GroupType, data.Scope, data.Category = groupTypeValues(group.GroupType)
 	data.ManagedBy = types.StringValue(group.ManagedBy)
 	data.ObjectGUID = types.StringValue(group.ObjectGUID)
 	data.ObjectSid = types.StringValue(group.ObjectSid)
 
+	email, diags := groupEmailModel(ctx, group, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Email = email
+
 	// Convert members slice
 	members := make([]types.String, len(group.Members))
 	for i, member := range group.Members {
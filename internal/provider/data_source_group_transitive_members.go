@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &GroupTransitiveMembersDataSource{}
+
+func NewGroupTransitiveMembersDataSource() datasource.DataSource {
+	return &GroupTransitiveMembersDataSource{}
+}
+
+// GroupTransitiveMembersDataSource defines the data source implementation.
+type GroupTransitiveMembersDataSource struct {
+	client *client.Client
+}
+
+// GroupTransitiveMembersDataSourceModel describes the data source data model.
+type GroupTransitiveMembersDataSourceModel struct {
+	ID      types.String        `tfsdk:"id"`
+	GroupDN types.String        `tfsdk:"group_dn"`
+	Members []NestedObjectModel `tfsdk:"members"`
+}
+
+// NestedObjectModel describes an object reached through nested membership.
+type NestedObjectModel struct {
+	DN             types.String `tfsdk:"dn"`
+	ObjectType     types.String `tfsdk:"object_type"`
+	SamAccountName types.String `tfsdk:"sam_account_name"`
+	ObjectGUID     types.String `tfsdk:"object_guid"`
+	Depth          types.Int64  `tfsdk:"depth"`
+}
+
+// nestedObjectAttributes returns the schema of a NestedObjectModel.
+func nestedObjectAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"dn": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Distinguished Name of the object.",
+		},
+		"object_type": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Structural object class, e.g. 'user', 'group', 'computer' or 'contact'.",
+		},
+		"sam_account_name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Security Account Manager (SAM) account name, if the object has one.",
+		},
+		"object_guid": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The objectGUID of the object.",
+		},
+		"depth": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Nesting depth: 1 for a direct membership, 2 for membership through one nested group, and so on. 0 if the chain passes through objects outside the provider's base DN.",
+		},
+	}
+}
+
+// nestedObjectModels converts client nested objects to their data model.
+func nestedObjectModels(objects []*client.NestedObject) []NestedObjectModel {
+	models := make([]NestedObjectModel, len(objects))
+	for i, object := range objects {
+		models[i] = NestedObjectModel{
+			DN:             types.StringValue(object.DN),
+			ObjectType:     types.StringValue(object.ObjectType),
+			SamAccountName: types.StringValue(object.SamAccountName),
+			ObjectGUID:     types.StringValue(object.ObjectGUID),
+			Depth:          types.Int64Value(int64(object.Depth)),
+		}
+	}
+	return models
+}
+
+func (d *GroupTransitiveMembersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_transitive_members"
+}
+
+func (d *GroupTransitiveMembersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches every object that is a member of an Active Directory group, directly or through nested groups, using the LDAP_MATCHING_RULE_IN_CHAIN matching rule. Membership through the primary group (primaryGroupID) is not included.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for this data source (same as group_dn).",
+			},
+			"group_dn": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Distinguished Name of the group.",
+			},
+			"members": schema.SetNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Set of direct and nested members of the group.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: nestedObjectAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *GroupTransitiveMembersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *GroupTransitiveMembersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GroupTransitiveMembersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := d.client.GetTransitiveMembers(data.GroupDN.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read transitive group members, got error: %s", err))
+		return
+	}
+
+	// Map response to the data model
+	data.ID = data.GroupDN
+	data.Members = nestedObjectModels(members)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
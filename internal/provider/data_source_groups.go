@@ -3,9 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hknerts/terraform-provider-adgroups/internal/client"
 )
@@ -24,21 +27,32 @@ type GroupsDataSource struct {
 
 // GroupsDataSourceModel describes the data source data model.
 type GroupsDataSourceModel struct {
-	ID     types.String                   `tfsdk:"id"`
-	Filter types.String                   `tfsdk:"filter"`
-	Groups []GroupsDataSourceGroupModel `tfsdk:"groups"`
+	ID            types.String                 `tfsdk:"id"`
+	SearchBase    types.String                 `tfsdk:"search_base"`
+	Scope         types.String                 `tfsdk:"scope"`
+	Filter        types.String                 `tfsdk:"filter"`
+	NamePrefix    types.String                 `tfsdk:"name_prefix"`
+	NameRegex     types.String                 `tfsdk:"name_regex"`
+	GroupScope    types.String                 `tfsdk:"group_scope"`
+	GroupCategory types.String                 `tfsdk:"group_category"`
+	ManagedBy     types.String                 `tfsdk:"managed_by"`
+	HasMembers    types.Bool                   `tfsdk:"has_members"`
+	Groups        []GroupsDataSourceGroupModel `tfsdk:"groups"`
 }
 
 type GroupsDataSourceGroupModel struct {
-	DN             types.String `tfsdk:"dn"`
-	CN             types.String `tfsdk:"cn"`
-	Name           types.String `tfsdk:"name"`
-	SamAccountName types.String `tfsdk:"sam_account_name"`
-	Description    types.String `tfsdk:"description"`
-	GroupType      types.Int64  `tfsdk:"group_type"`
-	ManagedBy      types.String `tfsdk:"managed_by"`
-	ObjectGUID     types.String `tfsdk:"object_guid"`
-	ObjectSid      types.String `tfsdk:"object_sid"`
+	DN             types.String     `tfsdk:"dn"`
+	CN             types.String     `tfsdk:"cn"`
+	Name           types.String     `tfsdk:"name"`
+	SamAccountName types.String     `tfsdk:"sam_account_name"`
+	Description    types.String     `tfsdk:"description"`
+	GroupType      types.Int64      `tfsdk:"group_type"`
+	Scope          types.String     `tfsdk:"scope"`
+	Category       types.String     `tfsdk:"category"`
+	ManagedBy      types.String     `tfsdk:"managed_by"`
+	ObjectGUID     types.String     `tfsdk:"object_guid"`
+	ObjectSid      types.String     `tfsdk:"object_sid"`
+	Email          *GroupEmailModel `tfsdk:"email"`
 }
 
 func (d *GroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -47,15 +61,62 @@ func (d *GroupsDataSource) Metadata(ctx context.Context, req datasource.Metadata
 
 func (d *GroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Fetches a list of Active Directory groups.",
+		MarkdownDescription: "Fetches a list of Active Directory groups. All structured filters are combined with each other and with `filter`.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Unique identifier for this data source.",
 			},
+			"search_base": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Distinguished Name to start the search from. Defaults to the provider's base DN.",
+			},
+			"scope": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Search scope: 'base', 'one' or 'subtree'. Defaults to 'subtree'.",
+				Validators: []validator.String{
+					stringOneOf("base", "one", "subtree"),
+				},
+			},
 			"filter": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "LDAP filter to apply when searching for groups. Defaults to '(objectClass=group)'.",
+				MarkdownDescription: "Additional raw LDAP filter to apply when searching for groups, e.g. '(description=*finance*)'. Always combined with '(objectClass=group)'.",
+				Validators: []validator.String{
+					ldapFilter(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return groups whose CN starts with this prefix.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return groups whose CN matches this regular expression (RE2 syntax). Applied after the LDAP search.",
+				Validators: []validator.String{
+					validRegex(),
+				},
+			},
+			"group_scope": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return groups with this scope: 'global', 'domain_local' or 'universal'.",
+				Validators: []validator.String{
+					stringOneOf(client.GroupScopeGlobal, client.GroupScopeDomainLocal, client.GroupScopeUniversal),
+				},
+			},
+			"group_category": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return groups of this category: 'security' or 'distribution'.",
+				Validators: []validator.String{
+					stringOneOf(client.GroupCategorySecurity, client.GroupCategoryDistribution),
+				},
+			},
+			"managed_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return groups managed by the object with this Distinguished Name.",
+			},
+			"has_members": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "If true, only return groups with at least one member; if false, only return empty groups.",
 			},
 			"groups": schema.ListNestedAttribute{
 				Computed:            true,
@@ -84,7 +145,15 @@ func (d *GroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 						},
 						"group_type": schema.Int64Attribute{
 							Computed:            true,
-							MarkdownDescription: "Group type value.",
+							MarkdownDescription: "Raw groupType bitmask.",
+						},
+						"scope": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Group scope: 'global', 'domain_local' or 'universal'.",
+						},
+						"category": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Group category: 'security' or 'distribution'.",
 						},
 						"managed_by": schema.StringAttribute{
 							Computed:            true,
@@ -98,6 +167,7 @@ func (d *GroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 							Computed:            true,
 							MarkdownDescription: "The objectSid of the group.",
 						},
+						"email": groupEmailDataSourceAttribute(),
 					},
 				},
 			},
@@ -135,20 +205,43 @@ func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	filter := "(objectClass=group)"
-	if !data.Filter.IsNull() && !data.Filter.IsUnknown() {
-		filter = data.Filter.ValueString()
+	scope, err := client.ParseScope(data.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Search Scope", err.Error())
+		return
 	}
 
-	groups, err := d.client.ListGroups(filter)
+	filter, err := groupsFilter(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Search Filter", err.Error())
+		return
+	}
+
+	groups, err := d.client.ListGroups(data.SearchBase.ValueString(), scope, filter)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list groups, got error: %s", err))
 		return
 	}
 
+	// LDAP has no regular expression matching, so filter client-side
+	if !data.NameRegex.IsNull() {
+		nameRegex, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Name Regex", err.Error())
+			return
+		}
+
+		matched := groups[:0]
+		for _, group := range groups {
+			if nameRegex.MatchString(group.CN) {
+				matched = append(matched, group)
+			}
+		}
+		groups = matched
+	}
+
 	// Map response to the data model
 	data.ID = types.StringValue("groups")
-	data.Filter = types.StringValue(filter)
 
 	groupModels := make([]GroupsDataSourceGroupModel, len(groups))
 	for i, group := range groups {
@@ -162,16 +255,57 @@ func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			ObjectGUID:     types.StringValue(group.ObjectGUID),
 			ObjectSid:      types.StringValue(group.ObjectSid),
 		}
-		
-		// Parse group type if available
-		if group.GroupType != "" {
-			if groupTypeInt, parseErr := fmt.Sscanf(group.GroupType, "%d", new(int64)); parseErr == nil && groupTypeInt == 1 {
-				groupModels[i].GroupType = types.Int64Value(int64(groupTypeInt))
-			}
-		}
+		groupModels[i].GroupType, groupModels[i].Scope, groupModels[i].Category = groupTypeValues(group.GroupType)
+
+		email, diags := groupEmailModel(ctx, group, nil)
+		resp.Diagnostics.Append(diags...)
+		groupModels[i].Email = email
+	}
+	if resp.Diagnostics.HasError() {
+		return
 	}
 	data.Groups = groupModels
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// groupsFilter combines the raw filter with the structured search arguments.
+func groupsFilter(data GroupsDataSourceModel) (string, error) {
+	var filter strings.Builder
+	filter.WriteString(data.Filter.ValueString())
+
+	if !data.NamePrefix.IsNull() {
+		fmt.Fprintf(&filter, "(cn=%s*)", client.EscapeFilter(data.NamePrefix.ValueString()))
+	}
+
+	if !data.GroupScope.IsNull() {
+		scopeFilter, err := client.GroupScopeFilter(data.GroupScope.ValueString())
+		if err != nil {
+			return "", err
+		}
+		filter.WriteString(scopeFilter)
+	}
+
+	if !data.GroupCategory.IsNull() {
+		categoryFilter, err := client.GroupCategoryFilter(data.GroupCategory.ValueString())
+		if err != nil {
+			return "", err
+		}
+		filter.WriteString(categoryFilter)
+	}
+
+	if !data.ManagedBy.IsNull() {
+		fmt.Fprintf(&filter, "(managedBy=%s)", client.EscapeFilter(data.ManagedBy.ValueString()))
+	}
+
+	if !data.HasMembers.IsNull() {
+		if data.HasMembers.ValueBool() {
+			filter.WriteString("(member=*)")
+		} else {
+			filter.WriteString("(!(member=*))")
+		}
+	}
+
+	return filter.String(), nil
+}
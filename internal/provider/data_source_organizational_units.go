@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OrganizationalUnitsDataSource{}
+
+func NewOrganizationalUnitsDataSource() datasource.DataSource {
+	return &OrganizationalUnitsDataSource{}
+}
+
+// OrganizationalUnitsDataSource defines the data source implementation.
+type OrganizationalUnitsDataSource struct {
+	client *client.Client
+}
+
+// OrganizationalUnitsDataSourceModel describes the data source data model.
+type OrganizationalUnitsDataSourceModel struct {
+	ID                  types.String                                           `tfsdk:"id"`
+	SearchBase          types.String                                           `tfsdk:"search_base"`
+	Scope               types.String                                           `tfsdk:"scope"`
+	Filter              types.String                                           `tfsdk:"filter"`
+	OrganizationalUnits []OrganizationalUnitsDataSourceOrganizationalUnitModel `tfsdk:"organizational_units"`
+}
+
+type OrganizationalUnitsDataSourceOrganizationalUnitModel struct {
+	DN          types.String `tfsdk:"dn"`
+	Name        types.String `tfsdk:"name"`
+	ParentDN    types.String `tfsdk:"parent_dn"`
+	Description types.String `tfsdk:"description"`
+	ObjectGUID  types.String `tfsdk:"object_guid"`
+}
+
+func (d *OrganizationalUnitsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organizational_units"
+}
+
+func (d *OrganizationalUnitsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches a set of Active Directory organizational units.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for this data source.",
+			},
+			"search_base": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Distinguished Name to start the search from. Defaults to the provider's base DN.",
+			},
+			"scope": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Search scope: 'base', 'one' or 'subtree'. Defaults to 'subtree'.",
+				Validators: []validator.String{
+					stringOneOf("base", "one", "subtree"),
+				},
+			},
+			"filter": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Additional raw LDAP filter, e.g. '(description=*)'. Always combined with '(objectClass=organizationalUnit)'.",
+				Validators: []validator.String{
+					ldapFilter(),
+				},
+			},
+			"organizational_units": schema.SetNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Set of organizational units matching the search.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"dn": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Distinguished Name of the organizational unit.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the organizational unit.",
+						},
+						"parent_dn": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Distinguished Name of the parent container.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Description of the organizational unit.",
+						},
+						"object_guid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The objectGUID of the organizational unit.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrganizationalUnitsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrganizationalUnitsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrganizationalUnitsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope, err := client.ParseScope(data.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Search Scope", err.Error())
+		return
+	}
+
+	ous, err := d.client.ListOrganizationalUnits(data.SearchBase.ValueString(), scope, data.Filter.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list organizational units, got error: %s", err))
+		return
+	}
+
+	// Map response to the data model
+	data.ID = types.StringValue("organizational_units")
+
+	ouModels := make([]OrganizationalUnitsDataSourceOrganizationalUnitModel, len(ous))
+	for i, ou := range ous {
+		ouModels[i] = OrganizationalUnitsDataSourceOrganizationalUnitModel{
+			DN:          types.StringValue(ou.DN),
+			Name:        types.StringValue(ou.Name),
+			ParentDN:    types.StringValue(ou.ParentDN),
+			Description: types.StringValue(ou.Description),
+			ObjectGUID:  types.StringValue(ou.ObjectGUID),
+		}
+	}
+	data.OrganizationalUnits = ouModels
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &QuarantinedGroupsDataSource{}
+
+func NewQuarantinedGroupsDataSource() datasource.DataSource {
+	return &QuarantinedGroupsDataSource{}
+}
+
+// QuarantinedGroupsDataSource defines the data source implementation.
+type QuarantinedGroupsDataSource struct {
+	client *client.Client
+}
+
+// QuarantinedGroupsDataSourceModel describes the data source data model.
+type QuarantinedGroupsDataSourceModel struct {
+	ID            types.String                            `tfsdk:"id"`
+	OlderThanDays types.Int64                             `tfsdk:"older_than_days"`
+	Groups        []QuarantinedGroupsDataSourceGroupModel `tfsdk:"groups"`
+}
+
+type QuarantinedGroupsDataSourceGroupModel struct {
+	DN                     types.String `tfsdk:"dn"`
+	SamAccountName         types.String `tfsdk:"sam_account_name"`
+	OriginalSamAccountName types.String `tfsdk:"original_sam_account_name"`
+	Info                   types.String `tfsdk:"info"`
+	QuarantinedAt          types.String `tfsdk:"quarantined_at"`
+	ObjectGUID             types.String `tfsdk:"object_guid"`
+	ObjectSid              types.String `tfsdk:"object_sid"`
+}
+
+func (d *QuarantinedGroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_quarantined_groups"
+}
+
+func (d *QuarantinedGroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the groups moved to the provider's `quarantine_ou` by `deletion_mode = \"quarantine\"`, e.g. to purge them after a retention period.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for this data source.",
+			},
+			"older_than_days": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only return groups quarantined at least this many days ago. Defaults to 0, returning all quarantined groups.",
+				Validators: []validator.Int64{
+					int64AtLeast(0),
+				},
+			},
+			"groups": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Quarantined groups, oldest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"dn": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Distinguished Name of the group in the quarantine OU.",
+						},
+						"sam_account_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The sAMAccountName of the group, made unique on quarantine.",
+						},
+						"original_sam_account_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The sAMAccountName the group had before quarantine.",
+						},
+						"info": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The info stamped on quarantine, including the group's former DN and sAMAccountName.",
+						},
+						"quarantined_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the group was quarantined, in RFC 3339 format.",
+						},
+						"object_guid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The objectGUID of the group.",
+						},
+						"object_sid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The objectSid of the group.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *QuarantinedGroupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *QuarantinedGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data QuarantinedGroupsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client.QuarantineOU() == "" {
+		resp.Diagnostics.AddError(
+			"Missing Quarantine OU Configuration",
+			"Listing quarantined groups requires the provider's quarantine_ou to be set.",
+		)
+		return
+	}
+
+	before := time.Now().Add(-time.Duration(data.OlderThanDays.ValueInt64()) * 24 * time.Hour)
+
+	groups, err := d.client.ListQuarantinedGroups(before)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list quarantined groups, got error: %s", err))
+		return
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].QuarantinedAt.Before(groups[j].QuarantinedAt)
+	})
+
+	// Map response to the data model
+	data.ID = types.StringValue("quarantined_groups")
+
+	groupModels := make([]QuarantinedGroupsDataSourceGroupModel, len(groups))
+	for i, group := range groups {
+		groupModels[i] = QuarantinedGroupsDataSourceGroupModel{
+			DN:                     types.StringValue(group.DN),
+			SamAccountName:         types.StringValue(group.SamAccountName),
+			OriginalSamAccountName: stringValueOrNull(group.OriginalSamAccountName),
+			Info:                   types.StringValue(group.Info),
+			QuarantinedAt:          types.StringValue(group.QuarantinedAt.Format(time.RFC3339)),
+			ObjectGUID:             types.StringValue(group.ObjectGUID),
+			ObjectSid:              types.StringValue(group.ObjectSid),
+		}
+	}
+	data.Groups = groupModels
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -24,18 +24,25 @@ type UserDataSource struct {
 
 // UserDataSourceModel describes the data source data model.
 type UserDataSourceModel struct {
-	ID             types.String   `tfsdk:"id"`
-	DN             types.String   `tfsdk:"dn"`
-	CN             types.String   `tfsdk:"cn"`
-	SamAccountName types.String   `tfsdk:"sam_account_name"`
-	UserPrincipalName types.String `tfsdk:"user_principal_name"`
-	DisplayName    types.String   `tfsdk:"display_name"`
-	GivenName      types.String   `tfsdk:"given_name"`
-	Surname        types.String   `tfsdk:"surname"`
-	Email          types.String   `tfsdk:"email"`
-	MemberOf       []types.String `tfsdk:"member_of"`
-	ObjectGUID     types.String   `tfsdk:"object_guid"`
-	ObjectSid      types.String   `tfsdk:"object_sid"`
+	ID                 types.String   `tfsdk:"id"`
+	DN                 types.String   `tfsdk:"dn"`
+	CN                 types.String   `tfsdk:"cn"`
+	SamAccountName     types.String   `tfsdk:"sam_account_name"`
+	UserPrincipalName  types.String   `tfsdk:"user_principal_name"`
+	DisplayName        types.String   `tfsdk:"display_name"`
+	GivenName          types.String   `tfsdk:"given_name"`
+	Surname            types.String   `tfsdk:"surname"`
+	Email              types.String   `tfsdk:"email"`
+	Enabled            types.Bool     `tfsdk:"enabled"`
+	Manager            types.String   `tfsdk:"manager"`
+	Department         types.String   `tfsdk:"department"`
+	Title              types.String   `tfsdk:"title"`
+	EmployeeID         types.String   `tfsdk:"employee_id"`
+	WhenCreated        types.String   `tfsdk:"when_created"`
+	LastLogonTimestamp types.String   `tfsdk:"last_logon_timestamp"`
+	MemberOf           []types.String `tfsdk:"member_of"`
+	ObjectGUID         types.String   `tfsdk:"object_guid"`
+	ObjectSid          types.String   `tfsdk:"object_sid"`
 }
 
 func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -53,7 +60,7 @@ func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 			"dn": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Distinguished Name of the user. Either 'dn' or 'sam_account_name' must be specified.",
+				MarkdownDescription: "Distinguished Name of the user. Exactly one of 'dn', 'sam_account_name', 'user_principal_name', 'email' or 'object_guid' must be specified.",
 			},
 			"cn": schema.StringAttribute{
 				Computed:            true,
@@ -62,11 +69,12 @@ func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 			"sam_account_name": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Security Account Manager (SAM) account name. Either 'dn' or 'sam_account_name' must be specified.",
+				MarkdownDescription: "Security Account Manager (SAM) account name. Can be used to look up the user.",
 			},
 			"user_principal_name": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "User Principal Name (UPN).",
+				MarkdownDescription: "User Principal Name (UPN). Can be used to look up the user.",
 			},
 			"display_name": schema.StringAttribute{
 				Computed:            true,
@@ -81,8 +89,37 @@ func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "Surname (last name) of the user.",
 			},
 			"email": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Email address of the user. Can be used to look up the user.",
+			},
+			"enabled": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the account is enabled, derived from userAccountControl.",
+			},
+			"manager": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Distinguished Name of the user's manager.",
+			},
+			"department": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Department of the user.",
+			},
+			"title": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Job title of the user.",
+			},
+			"employee_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Employee ID of the user.",
+			},
+			"when_created": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time the account was created, in RFC 3339 format.",
+			},
+			"last_logon_timestamp": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Email address of the user.",
+				MarkdownDescription: "Replicated last logon time of the account, in RFC 3339 format. Empty if the account has never logged on. This value can lag the actual last logon by up to 14 days.",
 			},
 			"member_of": schema.ListAttribute{
 				ElementType:         types.StringType,
@@ -90,8 +127,9 @@ func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "List of Distinguished Names of groups this user is a member of.",
 			},
 			"object_guid": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "The objectGUID of the user.",
+				MarkdownDescription: "The objectGUID of the user. Can be used to look up the user.",
 			},
 			"object_sid": schema.StringAttribute{
 				Computed:            true,
@@ -131,56 +169,75 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	// For now, this is a placeholder implementation
-	// In a full implementation, you would add user lookup methods to the client
-	resp.Diagnostics.AddError(
-		"Not Implemented",
-		"User data source is not yet implemented. This is a placeholder for future functionality.",
-	)
-	return
-
-	// TODO: Implement user lookup functionality
-	// var user *client.User
-	// var err error
-	//
-	// // Either DN or SAM account name must be specified
-	// if !data.DN.IsNull() && !data.DN.IsUnknown() {
-	//     user, err = d.client.GetUser(data.DN.ValueString())
-	// } else if !data.SamAccountName.IsNull() && !data.SamAccountName.IsUnknown() {
-	//     user, err = d.client.GetUserBySAM(data.SamAccountName.ValueString())
-	// } else {
-	//     resp.Diagnostics.AddError(
-	//         "Missing Required Attribute",
-	//         "Either 'dn' or 'sam_account_name' must be specified to look up the user.",
-	//     )
-	//     return
-	// }
-	//
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
-	//     return
-	// }
-	//
-	// // Map response to the data model
-	// data.ID = types.StringValue(user.DN)
-	// data.DN = types.StringValue(user.DN)
-	// data.CN = types.StringValue(user.CN)
-	// data.SamAccountName = types.StringValue(user.SamAccountName)
-	// data.UserPrincipalName = types.StringValue(user.UserPrincipalName)
-	// data.DisplayName = types.StringValue(user.DisplayName)
-	// data.GivenName = types.StringValue(user.GivenName)
-	// data.Surname = types.StringValue(user.Surname)
-	// data.Email = types.StringValue(user.Email)
-	// data.ObjectGUID = types.StringValue(user.ObjectGUID)
-	// data.ObjectSid = types.StringValue(user.ObjectSid)
-	//
-	// // Convert memberOf slice
-	// memberOf := make([]types.String, len(user.MemberOf))
-	// for i, member := range user.MemberOf {
-	//     memberOf[i] = types.StringValue(member)
-	// }
-	// data.MemberOf = memberOf
-	//
-	// // Save data into Terraform state
-	// resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	var lookups []string
+	for name, value := range map[string]types.String{
+		"dn":                  data.DN,
+		"sam_account_name":    data.SamAccountName,
+		"user_principal_name": data.UserPrincipalName,
+		"email":               data.Email,
+		"object_guid":         data.ObjectGUID,
+	} {
+		if !value.IsNull() && !value.IsUnknown() {
+			lookups = append(lookups, name)
+		}
+	}
+
+	if len(lookups) != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid Lookup Attributes",
+			"Exactly one of 'dn', 'sam_account_name', 'user_principal_name', 'email' or 'object_guid' must be specified to look up the user.",
+		)
+		return
+	}
+
+	var user *client.User
+	var err error
+
+	switch lookups[0] {
+	case "dn":
+		user, err = d.client.GetUser(data.DN.ValueString())
+	case "sam_account_name":
+		user, err = d.client.GetUserBySAM(data.SamAccountName.ValueString())
+	case "user_principal_name":
+		user, err = d.client.GetUserByUPN(data.UserPrincipalName.ValueString())
+	case "email":
+		user, err = d.client.GetUserByEmail(data.Email.ValueString())
+	case "object_guid":
+		user, err = d.client.GetUserByGUID(data.ObjectGUID.ValueString())
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	// Map response to the data model
+	data.ID = types.StringValue(user.DN)
+	data.DN = types.StringValue(user.DN)
+	data.CN = types.StringValue(user.CN)
+	data.SamAccountName = types.StringValue(user.SamAccountName)
+	data.UserPrincipalName = types.StringValue(user.UserPrincipalName)
+	data.DisplayName = types.StringValue(user.DisplayName)
+	data.GivenName = types.StringValue(user.GivenName)
+	data.Surname = types.StringValue(user.Surname)
+	data.Email = types.StringValue(user.Email)
+	data.Enabled = types.BoolValue(user.Enabled)
+	data.Manager = types.StringValue(user.Manager)
+	data.Department = types.StringValue(user.Department)
+	data.Title = types.StringValue(user.Title)
+	data.EmployeeID = types.StringValue(user.EmployeeID)
+	data.WhenCreated = types.StringValue(user.WhenCreated)
+	data.LastLogonTimestamp = types.StringValue(user.LastLogonTimestamp)
+	data.ObjectGUID = types.StringValue(user.ObjectGUID)
+	data.ObjectSid = types.StringValue(user.ObjectSid)
+
+	// Convert memberOf slice
+	memberOf := make([]types.String, len(user.MemberOf))
+	for i, member := range user.MemberOf {
+		memberOf[i] = types.StringValue(member)
+	}
+	data.MemberOf = memberOf
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UserTransitiveGroupsDataSource{}
+
+func NewUserTransitiveGroupsDataSource() datasource.DataSource {
+	return &UserTransitiveGroupsDataSource{}
+}
+
+// UserTransitiveGroupsDataSource defines the data source implementation.
+type UserTransitiveGroupsDataSource struct {
+	client *client.Client
+}
+
+// UserTransitiveGroupsDataSourceModel describes the data source data model.
+type UserTransitiveGroupsDataSourceModel struct {
+	ID     types.String        `tfsdk:"id"`
+	UserDN types.String        `tfsdk:"user_dn"`
+	Groups []NestedObjectModel `tfsdk:"groups"`
+}
+
+func (d *UserTransitiveGroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_transitive_groups"
+}
+
+func (d *UserTransitiveGroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches every group an Active Directory user is a member of, directly or through nested groups, using the LDAP_MATCHING_RULE_IN_CHAIN matching rule. The primary group (usually Domain Users) is not included.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for this data source (same as user_dn).",
+			},
+			"user_dn": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Distinguished Name of the user. Any other security principal, such as a computer or group, works as well.",
+			},
+			"groups": schema.SetNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Set of groups the user is a direct or nested member of.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: nestedObjectAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *UserTransitiveGroupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UserTransitiveGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserTransitiveGroupsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groups, err := d.client.GetTransitiveGroups(data.UserDN.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read transitive groups, got error: %s", err))
+		return
+	}
+
+	// Map response to the data model
+	data.ID = data.UserDN
+	data.Groups = nestedObjectModels(groups)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
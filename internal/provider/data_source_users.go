@@ -0,0 +1,285 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsersDataSource{}
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+// UsersDataSource defines the data source implementation.
+type UsersDataSource struct {
+	client *client.Client
+}
+
+// UsersDataSourceModel describes the data source data model.
+type UsersDataSourceModel struct {
+	ID          types.String               `tfsdk:"id"`
+	SearchBase  types.String               `tfsdk:"search_base"`
+	Scope       types.String               `tfsdk:"scope"`
+	Filter      types.String               `tfsdk:"filter"`
+	Department  types.String               `tfsdk:"department"`
+	Title       types.String               `tfsdk:"title"`
+	EnabledOnly types.Bool                 `tfsdk:"enabled_only"`
+	MemberOf    types.String               `tfsdk:"member_of"`
+	Users       []UsersDataSourceUserModel `tfsdk:"users"`
+}
+
+type UsersDataSourceUserModel struct {
+	DN                 types.String   `tfsdk:"dn"`
+	CN                 types.String   `tfsdk:"cn"`
+	SamAccountName     types.String   `tfsdk:"sam_account_name"`
+	UserPrincipalName  types.String   `tfsdk:"user_principal_name"`
+	DisplayName        types.String   `tfsdk:"display_name"`
+	GivenName          types.String   `tfsdk:"given_name"`
+	Surname            types.String   `tfsdk:"surname"`
+	Email              types.String   `tfsdk:"email"`
+	Enabled            types.Bool     `tfsdk:"enabled"`
+	Manager            types.String   `tfsdk:"manager"`
+	Department         types.String   `tfsdk:"department"`
+	Title              types.String   `tfsdk:"title"`
+	EmployeeID         types.String   `tfsdk:"employee_id"`
+	WhenCreated        types.String   `tfsdk:"when_created"`
+	LastLogonTimestamp types.String   `tfsdk:"last_logon_timestamp"`
+	MemberOf           []types.String `tfsdk:"member_of"`
+	ObjectGUID         types.String   `tfsdk:"object_guid"`
+	ObjectSid          types.String   `tfsdk:"object_sid"`
+}
+
+func (d *UsersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches a set of Active Directory users. All structured filters are combined with each other and with `filter`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for this data source.",
+			},
+			"search_base": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Distinguished Name to start the search from. Defaults to the provider's base DN.",
+			},
+			"scope": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Search scope: 'base', 'one' or 'subtree'. Defaults to 'subtree'.",
+				Validators: []validator.String{
+					stringOneOf("base", "one", "subtree"),
+				},
+			},
+			"filter": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Additional raw LDAP filter, e.g. '(company=Example)'.",
+				Validators: []validator.String{
+					ldapFilter(),
+				},
+			},
+			"department": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return users whose department matches exactly.",
+			},
+			"title": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return users whose title matches exactly.",
+			},
+			"enabled_only": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return enabled accounts.",
+			},
+			"member_of": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return direct members of the group with this Distinguished Name.",
+			},
+			"users": schema.SetNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Set of users matching the search.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"dn": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Distinguished Name of the user.",
+						},
+						"cn": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Common Name of the user.",
+						},
+						"sam_account_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Security Account Manager (SAM) account name.",
+						},
+						"user_principal_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "User Principal Name (UPN).",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Display name of the user.",
+						},
+						"given_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Given name (first name) of the user.",
+						},
+						"surname": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Surname (last name) of the user.",
+						},
+						"email": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Email address of the user.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the account is enabled, derived from userAccountControl.",
+						},
+						"manager": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Distinguished Name of the user's manager.",
+						},
+						"department": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Department of the user.",
+						},
+						"title": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Job title of the user.",
+						},
+						"employee_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Employee ID of the user.",
+						},
+						"when_created": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Time the account was created, in RFC 3339 format.",
+						},
+						"last_logon_timestamp": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Replicated last logon time of the account, in RFC 3339 format.",
+						},
+						"member_of": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "List of Distinguished Names of groups this user is a member of.",
+						},
+						"object_guid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The objectGUID of the user.",
+						},
+						"object_sid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The objectSid of the user.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UsersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope, err := client.ParseScope(data.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Search Scope", err.Error())
+		return
+	}
+
+	// Combine the structured filters with the raw filter
+	var filter strings.Builder
+	filter.WriteString(data.Filter.ValueString())
+	if !data.Department.IsNull() {
+		fmt.Fprintf(&filter, "(department=%s)", client.EscapeFilter(data.Department.ValueString()))
+	}
+	if !data.Title.IsNull() {
+		fmt.Fprintf(&filter, "(title=%s)", client.EscapeFilter(data.Title.ValueString()))
+	}
+	if data.EnabledOnly.ValueBool() {
+		filter.WriteString("(!(userAccountControl:1.2.840.113556.1.4.803:=2))")
+	}
+	if !data.MemberOf.IsNull() {
+		fmt.Fprintf(&filter, "(memberOf=%s)", client.EscapeFilter(data.MemberOf.ValueString()))
+	}
+
+	users, err := d.client.ListUsers(data.SearchBase.ValueString(), scope, filter.String())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list users, got error: %s", err))
+		return
+	}
+
+	// Map response to the data model
+	data.ID = types.StringValue("users")
+
+	userModels := make([]UsersDataSourceUserModel, len(users))
+	for i, user := range users {
+		memberOf := make([]types.String, len(user.MemberOf))
+		for j, member := range user.MemberOf {
+			memberOf[j] = types.StringValue(member)
+		}
+
+		userModels[i] = UsersDataSourceUserModel{
+			DN:                 types.StringValue(user.DN),
+			CN:                 types.StringValue(user.CN),
+			SamAccountName:     types.StringValue(user.SamAccountName),
+			UserPrincipalName:  types.StringValue(user.UserPrincipalName),
+			DisplayName:        types.StringValue(user.DisplayName),
+			GivenName:          types.StringValue(user.GivenName),
+			Surname:            types.StringValue(user.Surname),
+			Email:              types.StringValue(user.Email),
+			Enabled:            types.BoolValue(user.Enabled),
+			Manager:            types.StringValue(user.Manager),
+			Department:         types.StringValue(user.Department),
+			Title:              types.StringValue(user.Title),
+			EmployeeID:         types.StringValue(user.EmployeeID),
+			WhenCreated:        types.StringValue(user.WhenCreated),
+			LastLogonTimestamp: types.StringValue(user.LastLogonTimestamp),
+			MemberOf:           memberOf,
+			ObjectGUID:         types.StringValue(user.ObjectGUID),
+			ObjectSid:          types.StringValue(user.ObjectSid),
+		}
+	}
+	data.Users = userModels
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
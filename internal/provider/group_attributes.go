@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// extraAttributesType is the type of the group resource's attributes map.
+var extraAttributesType = types.ListType{ElemType: types.StringType}
+
+// reservedGroupAttributes are managed through dedicated resource arguments or
+// by the directory itself, and can't be set through the attributes map.
+var reservedGroupAttributes = map[string]bool{
+	"cn":                   true,
+	"name":                 true,
+	"distinguishedname":    true,
+	"objectclass":          true,
+	"objectcategory":       true,
+	"samaccountname":       true,
+	"samaccounttype":       true,
+	"grouptype":            true,
+	"description":          true,
+	"managedby":            true,
+	"member":               true,
+	"memberof":             true,
+	"objectguid":           true,
+	"objectsid":            true,
+	"ntsecuritydescriptor": true,
+	"instancetype":         true,
+
+	// Managed through the email block
+	"mail":                       true,
+	"mailnickname":               true,
+	"displayname":                true,
+	"proxyaddresses":             true,
+	"msexchhidefromaddresslists": true,
+	"authorig":                   true,
+	"dlmemsubmitperms":           true,
+	"msexchenablemoderation":     true,
+	"msexchmoderatedbylink":      true,
+
+	// Managed through the posix block
+	"gidnumber": true,
+	"memberuid": true,
+}
+
+// validateExtraAttributes checks the attributes map against the reserved
+// attributes and the AD schema.
+func validateExtraAttributes(ctx context.Context, c *client.Client, attributes types.Map, diags *diag.Diagnostics) {
+	if attributes.IsNull() || attributes.IsUnknown() {
+		return
+	}
+
+	for name, value := range attributes.Elements() {
+		attrPath := path.Root("attributes").AtMapKey(name)
+
+		if reservedGroupAttributes[strings.ToLower(name)] {
+			diags.AddAttributeError(
+				attrPath,
+				"Reserved Attribute",
+				fmt.Sprintf("The attribute %q is managed by a dedicated argument of this resource or by Active Directory and cannot be set through 'attributes'.", name),
+			)
+			continue
+		}
+
+		schema, err := c.GetAttributeSchema(name)
+		if err != nil {
+			diags.AddAttributeError(attrPath, "Unknown Attribute", fmt.Sprintf("Unable to validate attribute %q against the Active Directory schema: %s", name, err))
+			continue
+		}
+
+		if schema.ReadOnly() {
+			diags.AddAttributeError(
+				attrPath,
+				"Read-Only Attribute",
+				fmt.Sprintf("The attribute %q is system-only, constructed or a back link, and cannot be written.", name),
+			)
+			continue
+		}
+
+		values, ok := value.(types.List)
+		if ok && !values.IsUnknown() && schema.SingleValued && len(values.Elements()) > 1 {
+			diags.AddAttributeError(
+				attrPath,
+				"Single-Valued Attribute",
+				fmt.Sprintf("The attribute %q is single-valued in the Active Directory schema, but %d values were given.", name, len(values.Elements())),
+			)
+		}
+	}
+}
+
+// extraAttributesFromMap converts the attributes map to LDAP attribute values.
+func extraAttributesFromMap(ctx context.Context, attributes types.Map) (map[string][]string, diag.Diagnostics) {
+	result := make(map[string][]string)
+	if attributes.IsNull() || attributes.IsUnknown() {
+		return result, nil
+	}
+
+	diags := attributes.ElementsAs(ctx, &result, false)
+	return result, diags
+}
+
+// readExtraAttributes refreshes the managed keys of the attributes map from
+// AD. Only keys already present in current are read. Values that only
+// differ in order from current are kept as-is, since AD doesn't preserve the
+// order of multi-valued attributes.
+func readExtraAttributes(ctx context.Context, c *client.Client, dn string, current types.Map) (types.Map, diag.Diagnostics) {
+	managed, diags := extraAttributesFromMap(ctx, current)
+	if diags.HasError() || len(managed) == 0 {
+		return current, diags
+	}
+
+	names := make([]string, 0, len(managed))
+	for name := range managed {
+		names = append(names, name)
+	}
+
+	actual, err := c.GetAttributes(dn, names)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read group attributes, got error: %s", err))
+		return current, diags
+	}
+
+	for name, values := range actual {
+		if !sameValues(values, managed[name]) {
+			managed[name] = values
+		}
+	}
+
+	result, valueDiags := types.MapValueFrom(ctx, extraAttributesType, managed)
+	diags.Append(valueDiags...)
+	return result, diags
+}
+
+// extraAttributeUpdates returns the modifications needed to go from the
+// state attributes map to the planned one. Keys removed from the plan are
+// cleared.
+func extraAttributeUpdates(ctx context.Context, plan, state types.Map) (map[string][]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	planned, planDiags := extraAttributesFromMap(ctx, plan)
+	diags.Append(planDiags...)
+	current, stateDiags := extraAttributesFromMap(ctx, state)
+	diags.Append(stateDiags...)
+
+	updates := make(map[string][]string)
+	for name, values := range planned {
+		if sameValues(values, current[name]) {
+			continue
+		}
+		// Clearing an attribute that is already empty would fail
+		if len(values) == 0 && len(current[name]) == 0 {
+			continue
+		}
+		updates[name] = values
+	}
+
+	for name, values := range current {
+		if _, ok := planned[name]; !ok && len(values) > 0 {
+			updates[name] = []string{}
+		}
+	}
+
+	return updates, diags
+}
+
+// sameValues reports whether a and b hold the same values, ignoring order.
+func sameValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
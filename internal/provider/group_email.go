@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// GroupEmailModel describes the mail settings of a group.
+type GroupEmailModel struct {
+	Address                         types.String `tfsdk:"address"`
+	Alias                           types.String `tfsdk:"alias"`
+	DisplayName                     types.String `tfsdk:"display_name"`
+	ProxyAddresses                  types.Set    `tfsdk:"proxy_addresses"`
+	HiddenFromAddressLists          types.Bool   `tfsdk:"hidden_from_address_lists"`
+	AcceptMessagesOnlyFrom          types.Set    `tfsdk:"accept_messages_only_from"`
+	AcceptMessagesOnlyFromMembersOf types.Set    `tfsdk:"accept_messages_only_from_members_of"`
+	ModerationEnabled               types.Bool   `tfsdk:"moderation_enabled"`
+	ModeratedBy                     types.Set    `tfsdk:"moderated_by"`
+}
+
+// groupEmailAttributeTypes are the attribute types of GroupEmailModel.
+var groupEmailAttributeTypes = map[string]attr.Type{
+	"address":                              types.StringType,
+	"alias":                                types.StringType,
+	"display_name":                         types.StringType,
+	"proxy_addresses":                      types.SetType{ElemType: types.StringType},
+	"hidden_from_address_lists":            types.BoolType,
+	"accept_messages_only_from":            types.SetType{ElemType: types.StringType},
+	"accept_messages_only_from_members_of": types.SetType{ElemType: types.StringType},
+	"moderation_enabled":                   types.BoolType,
+	"moderated_by":                         types.SetType{ElemType: types.StringType},
+}
+
+// groupEmailModel maps the mail settings of a group to their data model.
+// Nil is returned for groups without a mail address. Lists that are empty in
+// prior, if not nil, stay empty sets instead of becoming null.
+func groupEmailModel(ctx context.Context, group *client.Group, prior *GroupEmailModel) (*GroupEmailModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if group.Mail == "" {
+		return nil, diags
+	}
+
+	// The primary address is implied by the mail address; anything else,
+	// including a primary address that doesn't match mail, is listed
+	var secondary []string
+	for _, proxy := range group.ProxyAddresses {
+		if strings.HasPrefix(proxy, client.ProxyAddressPrimarySMTP) &&
+			strings.EqualFold(strings.TrimPrefix(proxy, client.ProxyAddressPrimarySMTP), group.Mail) {
+			continue
+		}
+		secondary = append(secondary, proxy)
+	}
+
+	if prior == nil {
+		prior = &GroupEmailModel{}
+	}
+
+	setLike := func(prior types.Set, values []string) types.Set {
+		set, setDiags := setValueLike(ctx, prior, values)
+		diags.Append(setDiags...)
+		return set
+	}
+
+	return &GroupEmailModel{
+		Address:                         types.StringValue(group.Mail),
+		Alias:                           stringValueOrNull(group.MailNickname),
+		DisplayName:                     stringValueOrNull(group.DisplayName),
+		ProxyAddresses:                  setLike(prior.ProxyAddresses, secondary),
+		HiddenFromAddressLists:          types.BoolValue(group.HiddenFromAddressLists),
+		AcceptMessagesOnlyFrom:          setLike(prior.AcceptMessagesOnlyFrom, group.AuthOrig),
+		AcceptMessagesOnlyFromMembersOf: setLike(prior.AcceptMessagesOnlyFromMembersOf, group.DLMemSubmitPerms),
+		ModerationEnabled:               types.BoolValue(group.ModerationEnabled),
+		ModeratedBy:                     setLike(prior.ModeratedBy, group.ModeratedBy),
+	}, diags
+}
+
+// groupEmailValue converts the mail settings of a group to an object value,
+// keeping the empty lists of the prior object.
+func groupEmailValue(ctx context.Context, group *client.Group, prior types.Object) (types.Object, diag.Diagnostics) {
+	priorModel, diags := groupEmailFromObject(ctx, prior)
+	if diags.HasError() {
+		return types.ObjectNull(groupEmailAttributeTypes), diags
+	}
+
+	model, modelDiags := groupEmailModel(ctx, group, priorModel)
+	diags.Append(modelDiags...)
+	if diags.HasError() || model == nil {
+		return types.ObjectNull(groupEmailAttributeTypes), diags
+	}
+
+	value, valueDiags := types.ObjectValueFrom(ctx, groupEmailAttributeTypes, model)
+	diags.Append(valueDiags...)
+	return value, diags
+}
+
+// groupEmailFromObject reads the email object into its model. Nil is
+// returned if the object is null or unknown.
+func groupEmailFromObject(ctx context.Context, email types.Object) (*GroupEmailModel, diag.Diagnostics) {
+	if email.IsNull() || email.IsUnknown() {
+		return nil, nil
+	}
+
+	var model GroupEmailModel
+	diags := email.As(ctx, &model, basetypes.ObjectAsOptions{})
+	return &model, diags
+}
+
+// groupEmailLDAPAttributes returns the LDAP attributes for the email
+// object. Every mail attribute is included; those without a value are
+// empty, so a null object clears all of them.
+func groupEmailLDAPAttributes(ctx context.Context, email types.Object) (map[string][]string, diag.Diagnostics) {
+	attributes := map[string][]string{
+		"mail":                       {},
+		"mailNickname":               {},
+		"displayName":                {},
+		"proxyAddresses":             {},
+		"msExchHideFromAddressLists": {},
+		"authOrig":                   {},
+		"dLMemSubmitPerms":           {},
+		"msExchEnableModeration":     {},
+		"msExchModeratedByLink":      {},
+	}
+
+	model, diags := groupEmailFromObject(ctx, email)
+	if diags.HasError() || model == nil {
+		return attributes, diags
+	}
+
+	setValues := func(set types.Set) []string {
+		values := []string{}
+		if set.IsNull() || set.IsUnknown() {
+			return values
+		}
+		diags.Append(set.ElementsAs(ctx, &values, false)...)
+		sort.Strings(values)
+		return values
+	}
+
+	address := model.Address.ValueString()
+	attributes["mail"] = []string{address}
+	attributes["proxyAddresses"] = append([]string{client.ProxyAddressPrimarySMTP + address}, setValues(model.ProxyAddresses)...)
+	attributes["authOrig"] = setValues(model.AcceptMessagesOnlyFrom)
+	attributes["dLMemSubmitPerms"] = setValues(model.AcceptMessagesOnlyFromMembersOf)
+	attributes["msExchModeratedByLink"] = setValues(model.ModeratedBy)
+
+	if !model.Alias.IsNull() {
+		attributes["mailNickname"] = []string{model.Alias.ValueString()}
+	}
+	if !model.DisplayName.IsNull() {
+		attributes["displayName"] = []string{model.DisplayName.ValueString()}
+	}
+
+	// False is stored by clearing the attribute, which is how Exchange
+	// leaves groups that never had the setting
+	if model.HiddenFromAddressLists.ValueBool() {
+		attributes["msExchHideFromAddressLists"] = []string{client.FormatBoolean(true)}
+	}
+	if model.ModerationEnabled.ValueBool() {
+		attributes["msExchEnableModeration"] = []string{client.FormatBoolean(true)}
+	}
+
+	return attributes, diags
+}
+
+// groupEmailUpdates returns the modifications needed to go from the state
+// email object to the planned one.
+func groupEmailUpdates(ctx context.Context, plan, state types.Object) (map[string][]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	updates := make(map[string][]string)
+	if plan.Equal(state) {
+		return updates, diags
+	}
+
+	planned, planDiags := groupEmailLDAPAttributes(ctx, plan)
+	diags.Append(planDiags...)
+	current, stateDiags := groupEmailLDAPAttributes(ctx, state)
+	diags.Append(stateDiags...)
+
+	for name, values := range planned {
+		if sameValues(values, current[name]) {
+			continue
+		}
+		updates[name] = values
+	}
+
+	return updates, diags
+}
+
+// groupEmailSMTPAddresses returns every SMTP address of the email object:
+// the primary address followed by the secondary SMTP proxy addresses.
+func groupEmailSMTPAddresses(ctx context.Context, model *GroupEmailModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if model == nil || model.Address.IsNull() || model.Address.IsUnknown() {
+		return nil, diags
+	}
+
+	addresses := []string{model.Address.ValueString()}
+	if model.ProxyAddresses.IsNull() || model.ProxyAddresses.IsUnknown() {
+		return addresses, diags
+	}
+
+	var proxies []string
+	diags.Append(model.ProxyAddresses.ElementsAs(ctx, &proxies, false)...)
+	for _, proxy := range proxies {
+		if strings.HasPrefix(strings.ToLower(proxy), client.ProxyAddressSecondarySMTP) {
+			addresses = append(addresses, proxy[len(client.ProxyAddressSecondarySMTP):])
+		}
+	}
+
+	return addresses, diags
+}
+
+// validateGroupEmail checks the proxy addresses of the email object. The
+// primary SMTP address always comes from the address attribute.
+func validateGroupEmail(ctx context.Context, email types.Object, diags *diag.Diagnostics) {
+	model, modelDiags := groupEmailFromObject(ctx, email)
+	diags.Append(modelDiags...)
+	if model == nil || model.ProxyAddresses.IsNull() || model.ProxyAddresses.IsUnknown() {
+		return
+	}
+
+	proxyPath := path.Root("email").AtName("proxy_addresses")
+	for _, element := range model.ProxyAddresses.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() {
+			continue
+		}
+		proxy := value.ValueString()
+
+		prefix, address, found := strings.Cut(proxy, ":")
+		switch {
+		case !found || prefix == "" || address == "":
+			diags.AddAttributeError(
+				proxyPath,
+				"Invalid Proxy Address",
+				fmt.Sprintf("Proxy address %q must include its address type, e.g. 'smtp:alias@example.com' or 'X500:/o=...'.", proxy),
+			)
+		case prefix+":" == client.ProxyAddressPrimarySMTP:
+			diags.AddAttributeError(
+				proxyPath,
+				"Invalid Proxy Address",
+				fmt.Sprintf("Proxy address %q uses the primary 'SMTP:' prefix. The primary address is set through 'address'; list secondary addresses with the lower-case 'smtp:' prefix.", proxy),
+			)
+		case strings.EqualFold(prefix+":", client.ProxyAddressSecondarySMTP) && !model.Address.IsUnknown() && strings.EqualFold(address, model.Address.ValueString()):
+			diags.AddAttributeError(
+				proxyPath,
+				"Invalid Proxy Address",
+				fmt.Sprintf("Proxy address %q duplicates the primary address.", proxy),
+			)
+		}
+	}
+}
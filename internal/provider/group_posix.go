@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// GroupPosixModel describes the RFC 2307 settings of a group.
+type GroupPosixModel struct {
+	GIDNumber     types.Int64 `tfsdk:"gid_number"`
+	SyncMemberUID types.Bool  `tfsdk:"sync_member_uid"`
+	MemberUIDs    types.Set   `tfsdk:"member_uids"`
+}
+
+// groupPosixAttributeTypes are the attribute types of GroupPosixModel.
+var groupPosixAttributeTypes = map[string]attr.Type{
+	"gid_number":      types.Int64Type,
+	"sync_member_uid": types.BoolType,
+	"member_uids":     types.SetType{ElemType: types.StringType},
+}
+
+// groupPosixFromObject reads the posix object into its model. Nil is
+// returned if the object is null or unknown.
+func groupPosixFromObject(ctx context.Context, posix types.Object) (*GroupPosixModel, diag.Diagnostics) {
+	if posix.IsNull() || posix.IsUnknown() {
+		return nil, nil
+	}
+
+	var model GroupPosixModel
+	diags := posix.As(ctx, &model, basetypes.ObjectAsOptions{})
+	return &model, diags
+}
+
+// groupPosixValue converts the RFC 2307 attributes of a group to an object
+// value. The memberUid values are only included when they are synced.
+func groupPosixValue(ctx context.Context, group *client.Group, syncMemberUID bool) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	model := GroupPosixModel{
+		GIDNumber:     types.Int64Null(),
+		SyncMemberUID: types.BoolValue(syncMemberUID),
+		MemberUIDs:    types.SetNull(types.StringType),
+	}
+
+	if group.GIDNumber != "" {
+		gid, err := strconv.ParseInt(group.GIDNumber, 10, 64)
+		if err != nil {
+			diags.AddError("Invalid gidNumber", fmt.Sprintf("The group %s has an invalid gidNumber %q: %s", group.DN, group.GIDNumber, err))
+			return types.ObjectNull(groupPosixAttributeTypes), diags
+		}
+		model.GIDNumber = types.Int64Value(gid)
+	}
+
+	if syncMemberUID {
+		uids, setDiags := types.SetValueFrom(ctx, types.StringType, nonNilStrings(group.MemberUIDs))
+		diags.Append(setDiags...)
+		model.MemberUIDs = uids
+	}
+
+	value, valueDiags := types.ObjectValueFrom(ctx, groupPosixAttributeTypes, model)
+	diags.Append(valueDiags...)
+	return value, diags
+}
+
+// groupPosixUpdates returns the modifications needed to go from the state
+// posix settings to the planned ones. An unknown planned gidNumber is left
+// out, since it is allocated separately.
+func groupPosixUpdates(ctx context.Context, plan, state *GroupPosixModel) (map[string][]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	updates := make(map[string][]string)
+
+	var currentGID types.Int64
+	var currentUIDs []string
+	if state != nil {
+		currentGID = state.GIDNumber
+		if state.SyncMemberUID.ValueBool() && !state.MemberUIDs.IsNull() {
+			diags.Append(state.MemberUIDs.ElementsAs(ctx, &currentUIDs, false)...)
+		}
+	}
+
+	// Removing the posix settings clears the attributes they managed
+	if plan == nil {
+		if !currentGID.IsNull() {
+			updates["gidNumber"] = []string{}
+		}
+		if len(currentUIDs) > 0 {
+			updates["memberUid"] = []string{}
+		}
+		return updates, diags
+	}
+
+	if !plan.GIDNumber.IsUnknown() && !plan.GIDNumber.Equal(currentGID) {
+		if plan.GIDNumber.IsNull() {
+			if !currentGID.IsNull() {
+				updates["gidNumber"] = []string{}
+			}
+		} else {
+			updates["gidNumber"] = []string{strconv.FormatInt(plan.GIDNumber.ValueInt64(), 10)}
+		}
+	}
+
+	if plan.SyncMemberUID.ValueBool() && !plan.MemberUIDs.IsNull() && !plan.MemberUIDs.IsUnknown() {
+		var uids []string
+		diags.Append(plan.MemberUIDs.ElementsAs(ctx, &uids, false)...)
+
+		if !sameValues(uids, currentUIDs) && (len(uids) > 0 || len(currentUIDs) > 0) {
+			updates["memberUid"] = nonNilStrings(uids)
+		}
+	}
+
+	return updates, diags
+}
+
+// planPosix validates the planned posix settings and computes the memberUid
+// values expected from the current members of the group.
+func (r *GroupResource) planPosix(ctx context.Context, plan, state *GroupResourceModel, resp *resource.ModifyPlanResponse) {
+	model, diags := groupPosixFromObject(ctx, plan.Posix)
+	resp.Diagnostics.Append(diags...)
+	if model == nil || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var current *GroupPosixModel
+	if state != nil {
+		current, diags = groupPosixFromObject(ctx, state.Posix)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	gidPath := path.Root("posix").AtName("gid_number")
+	switch {
+	case model.GIDNumber.IsUnknown():
+		if _, _, ok := r.client.GIDNumberRange(); !ok {
+			resp.Diagnostics.AddAttributeError(
+				gidPath,
+				"Missing gidNumber Range",
+				"'gid_number' was not set, so it must be allocated, but the provider has no 'gid_number_min' and 'gid_number_max' configured.",
+			)
+			return
+		}
+	case !model.GIDNumber.IsNull() && (current == nil || !model.GIDNumber.Equal(current.GIDNumber)):
+		dns, err := r.client.FindGroupsByGIDNumber(model.GIDNumber.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(gidPath, "Client Error", fmt.Sprintf("Unable to check gidNumber for conflicts, got error: %s", err))
+			return
+		}
+
+		var others []string
+		for _, dn := range dns {
+			if state == nil || !strings.EqualFold(dn, state.DN.ValueString()) {
+				others = append(others, dn)
+			}
+		}
+		if len(others) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				gidPath,
+				"gidNumber Already In Use",
+				fmt.Sprintf("The gidNumber %d is already used by:\n  - %s", model.GIDNumber.ValueInt64(), strings.Join(others, "\n  - ")),
+			)
+			return
+		}
+	}
+
+	// A new group has no members yet
+	memberUIDs := types.SetNull(types.StringType)
+	if model.SyncMemberUID.ValueBool() {
+		uids := []string{}
+		if state != nil {
+			var err error
+			uids, err = r.client.GetPosixMemberUIDs(state.DN.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group members for memberUid, got error: %s", err))
+				return
+			}
+		}
+
+		memberUIDs, diags = types.SetValueFrom(ctx, types.StringType, uids)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("posix").AtName("member_uids"), memberUIDs)...)
+}
+
+// setGIDNumber stores the final gidNumber in the posix object.
+func setGIDNumber(ctx context.Context, model *GroupPosixModel, gid int64) (types.Object, diag.Diagnostics) {
+	model.GIDNumber = types.Int64Value(gid)
+	return types.ObjectValueFrom(ctx, groupPosixAttributeTypes, model)
+}
+
+// nonNilStrings returns values, or an empty slice if values is nil.
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
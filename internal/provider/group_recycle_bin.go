@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// restoreGroup reanimates the deleted group with the planned
+// restore_object_guid, or else the most recently deleted group with the
+// planned sAMAccountName, from the AD Recycle Bin into the planned OU, and
+// applies the planned group type and attributes to it. It returns "" if
+// there is no deleted group with that sAMAccountName, and the DN of the
+// restored group otherwise, also when reconciling its attributes failed.
+func (r *GroupResource) restoreGroup(data *GroupResourceModel, attributes map[string][]string) (string, error) {
+	samAccountName := data.CN.ValueString()
+	if values, ok := attributes["sAMAccountName"]; ok {
+		samAccountName = values[0]
+	}
+
+	// A pinned deleted group must exist
+	if !data.RestoreObjectGUID.IsNull() {
+		deleted, err := r.client.FindDeletedGroupByGUID(data.RestoreObjectGUID.ValueString())
+		if err != nil {
+			return "", err
+		}
+		return r.restoreDeletedGroup(data, attributes, deleted, samAccountName)
+	}
+
+	deleted, err := r.client.FindDeletedGroup(samAccountName)
+	if err != nil {
+		if client.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return r.restoreDeletedGroup(data, attributes, deleted, samAccountName)
+}
+
+// restoreDeletedGroup restores a deleted group found by restoreGroup
+func (r *GroupResource) restoreDeletedGroup(data *GroupResourceModel, attributes map[string][]string, deleted *client.DeletedGroup, samAccountName string) (string, error) {
+
+	dn := fmt.Sprintf("CN=%s,%s", client.EscapeDN(data.CN.ValueString()), data.OU.ValueString())
+	if err := r.client.RestoreDeletedObject(deleted.DN, dn); err != nil {
+		return "", err
+	}
+
+	group, err := r.client.GetGroup(dn)
+	if err != nil {
+		return dn, fmt.Errorf("unable to read restored group: %w", err)
+	}
+
+	groupType, err := strconv.ParseInt(group.GroupType, 10, 64)
+	if err != nil {
+		return dn, fmt.Errorf("restored group has an invalid groupType %q", group.GroupType)
+	}
+	if groupType != data.GroupType.ValueInt64() {
+		if err := r.client.SetGroupType(dn, groupType, data.GroupType.ValueInt64()); err != nil {
+			return dn, err
+		}
+	}
+
+	// Configured values are written, and managed attributes the
+	// configuration leaves unset are cleared if the restored group still has
+	// them. The email attributes are always in attributes, empty when unset.
+	names := []string{"description", "managedBy", "gidNumber", "memberUid", "adminDescription", "info"}
+	for name := range attributes {
+		if !containsFold(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	current, err := r.client.GetAttributes(dn, names)
+	if err != nil {
+		return dn, fmt.Errorf("unable to read restored group: %w", err)
+	}
+
+	updates := make(map[string][]string, len(names))
+	for name, values := range attributes {
+		if len(values) > 0 {
+			updates[name] = values
+		}
+	}
+	for _, name := range names {
+		if len(current[name]) == 0 || hasAttributeName(updates, name) {
+			continue
+		}
+
+		switch name {
+		case "adminDescription", "info":
+			// Only the stamps of an earlier quarantine are removed
+			if previous := client.UnquarantineValue(current[name][0]); previous == "" {
+				updates[name] = []string{}
+			} else if previous != current[name][0] {
+				updates[name] = []string{previous}
+			}
+		default:
+			updates[name] = []string{}
+		}
+	}
+
+	// A group deleted while quarantined still has its quarantine name
+	if !strings.EqualFold(group.SamAccountName, samAccountName) {
+		updates["sAMAccountName"] = []string{samAccountName}
+	}
+
+	if len(updates) > 0 {
+		if err := r.client.UpdateGroup(dn, updates); err != nil {
+			return dn, err
+		}
+	}
+
+	return dn, nil
+}
+
+// hasAttributeName reports whether attributes has a key for name, ignoring
+// case
+func hasAttributeName(attributes map[string][]string, name string) bool {
+	for n := range attributes {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
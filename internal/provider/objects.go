@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// dnValueLike returns a DN read from the directory, keeping the configured
+// spelling if it names the same object.
+func dnValueLike(configured types.String, dn string) types.String {
+	if client.DNEqual(configured.ValueString(), dn) {
+		return configured
+	}
+	return stringValueOrNull(dn)
+}
+
+// parentDNLike returns the DN of the container of an object, keeping the
+// configured spelling if it names the same container.
+func parentDNLike(configured types.String, dn string) types.String {
+	parent, err := client.ParentDN(dn)
+	if err != nil {
+		return configured
+	}
+	return dnValueLike(configured, parent)
+}
+
+// planRenamedDN marks dn as unknown when the plan renames or moves the
+// object. Otherwise dn keeps its prior value through UseStateForUnknown, so
+// resources referencing it aren't replaced by unrelated changes.
+func planRenamedDN(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, nameAttribute, parentAttribute string) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	for _, attribute := range []string{nameAttribute, parentAttribute} {
+		var plan, state types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attribute), &plan)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attribute), &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !plan.Equal(state) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("dn"), types.StringUnknown())...)
+			return
+		}
+	}
+}
+
+// abortCreatedObject deletes an object that was created but could not be
+// read back, so the next apply doesn't fail with "already exists". kind
+// names the object type in messages, e.g. "managed service account".
+func abortCreatedObject(kind, dn string, cause error, deleteObject func(dn string) error, diags *diag.Diagnostics) {
+	words := strings.Fields(kind)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	summary := strings.Join(words, " ") + " Creation Failed"
+
+	if err := deleteObject(dn); err != nil {
+		diags.AddError(
+			summary,
+			fmt.Sprintf("The %s %s was created but could not be read back (%s), and deleting it also failed (%s). Delete or import it manually.", kind, dn, cause, err),
+		)
+		return
+	}
+
+	diags.AddError(
+		summary,
+		fmt.Sprintf("The %s %s was created but could not be read back, so it was deleted again: %s", kind, dn, cause),
+	)
+}
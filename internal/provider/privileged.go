@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// allowPrivilegedGroupAttribute is the opt-in that resources writing to
+// groups offer for privileged groups
+var allowPrivilegedGroupAttribute = schema.BoolAttribute{
+	Optional:            true,
+	Computed:            true,
+	Default:             booldefault.StaticBool(false),
+	MarkdownDescription: "Allow writes to a privileged group (adminCount=1, well-known groups such as Domain Admins, or the provider's `privileged_groups`). Also requires `privileged_writes_enabled` on the provider. Defaults to false.",
+}
+
+// checkPrivilegedGroup fails the plan if the group is privileged, unless
+// the resource opted in with allow_privileged_group and the provider has
+// privileged_writes_enabled. Groups that don't exist yet and objects that
+// aren't groups are not checked.
+func checkPrivilegedGroup(c *client.Client, groupDN string, allow types.Bool, diags *diag.Diagnostics) {
+	reason, err := c.PrivilegedGroupReason(groupDN)
+	if err != nil {
+		if client.IsNotFound(err) {
+			return
+		}
+		diags.AddError("Client Error", fmt.Sprintf("Unable to check whether %s is a privileged group, got error: %s", groupDN, err))
+		return
+	}
+
+	if reason == "" || (allow.ValueBool() && c.PrivilegedWritesEnabled()) {
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root("allow_privileged_group"),
+		"Privileged Group",
+		fmt.Sprintf("The group %s is privileged because %s. Writing to it requires 'allow_privileged_group = true' on this resource "+
+			"and 'privileged_writes_enabled = true' on the provider.", groupDN, reason),
+	)
+}
@@ -2,12 +2,16 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hknerts/terraform-provider-adgroups/internal/client"
 )
@@ -32,6 +36,18 @@ type ADGroupsProviderModel struct {
 	Password types.String `tfsdk:"password"`
 	UseTLS   types.Bool   `tfsdk:"use_tls"`
 	Insecure types.Bool   `tfsdk:"insecure"`
+
+	GlobalCatalogServer types.String `tfsdk:"global_catalog_server"`
+
+	GIDNumberMin       types.Int64  `tfsdk:"gid_number_min"`
+	GIDNumberMax       types.Int64  `tfsdk:"gid_number_max"`
+	GIDNumberCounterDN types.String `tfsdk:"gid_number_counter_dn"`
+
+	PrivilegedWritesEnabled types.Bool `tfsdk:"privileged_writes_enabled"`
+	PrivilegedGroups        types.List `tfsdk:"privileged_groups"`
+
+	DeletionMode types.String `tfsdk:"deletion_mode"`
+	QuarantineOU types.String `tfsdk:"quarantine_ou"`
 }
 
 func (p *ADGroupsProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -74,6 +90,42 @@ func (p *ADGroupsProvider) Schema(ctx context.Context, req provider.SchemaReques
 				MarkdownDescription: "Skip TLS certificate verification (default: false). Can also be set via the `AD_INSECURE` environment variable.",
 				Optional:            true,
 			},
+			"global_catalog_server": schema.StringAttribute{
+				MarkdownDescription: "Global catalog host used to check mail addresses across the forest, on port 3268 (3269 with TLS). Defaults to `server_host`. If it can't be reached, only the domain is checked and a warning is shown. Can also be set via the `AD_GLOBAL_CATALOG_SERVER` environment variable.",
+				Optional:            true,
+			},
+			"gid_number_min": schema.Int64Attribute{
+				MarkdownDescription: "Lowest gidNumber allocated to POSIX groups that don't set one. Can also be set via the `AD_GID_NUMBER_MIN` environment variable.",
+				Optional:            true,
+			},
+			"gid_number_max": schema.Int64Attribute{
+				MarkdownDescription: "Highest gidNumber allocated to POSIX groups that don't set one. Can also be set via the `AD_GID_NUMBER_MAX` environment variable.",
+				Optional:            true,
+			},
+			"gid_number_counter_dn": schema.StringAttribute{
+				MarkdownDescription: "Object whose `msSFU30MaxGidNumber` reserves allocated gidNumbers. Allocations start above the highest gidNumber in use and advance the counter atomically, so concurrent runs never hand out the same number. Defaults to the NIS domain object under `CN=ypservers,CN=ypServ30,CN=RpcServices,CN=System`, if there is one; without a counter, numbers aren't reserved. Can also be set via the `AD_GID_NUMBER_COUNTER_DN` environment variable.",
+				Optional:            true,
+			},
+			"privileged_writes_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Allow resources that set `allow_privileged_group` to write to privileged groups (adminCount=1, well-known groups such as Domain Admins, or `privileged_groups`). Writes to privileged groups fail during planning otherwise (default: false). Can also be set via the `AD_PRIVILEGED_WRITES_ENABLED` environment variable.",
+				Optional:            true,
+			},
+			"privileged_groups": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional groups, by DN or sAMAccountName, to guard like privileged groups.",
+				Optional:            true,
+			},
+			"deletion_mode": schema.StringAttribute{
+				MarkdownDescription: "How destroyed groups are removed: `delete` (default) deletes them, `quarantine` clears their members and moves them to `quarantine_ou`, keeping their SID. Can also be set via the `AD_DELETION_MODE` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(client.DeletionModeDelete, client.DeletionModeQuarantine),
+				},
+			},
+			"quarantine_ou": schema.StringAttribute{
+				MarkdownDescription: "Distinguished Name of the OU destroyed groups are moved to when `deletion_mode` is `quarantine`. Can also be set via the `AD_QUARANTINE_OU` environment variable.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -168,6 +220,73 @@ func (p *ADGroupsProvider) Configure(ctx context.Context, req provider.Configure
 		insecure = true
 	}
 
+	gidNumberMin, ok := int64Setting(data.GIDNumberMin, "AD_GID_NUMBER_MIN", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	gidNumberMax, ok := int64Setting(data.GIDNumberMax, "AD_GID_NUMBER_MAX", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	if (gidNumberMin == 0) != (gidNumberMax == 0) || gidNumberMin < 0 || gidNumberMin > gidNumberMax {
+		resp.Diagnostics.AddError(
+			"Invalid gidNumber Range Configuration",
+			"gid_number_min and gid_number_max must either both be set, with 0 < gid_number_min <= gid_number_max, or both be left unset.",
+		)
+		return
+	}
+
+	globalCatalogServer := data.GlobalCatalogServer.ValueString()
+	if globalCatalogServer == "" {
+		globalCatalogServer = os.Getenv("AD_GLOBAL_CATALOG_SERVER")
+	}
+
+	gidNumberCounterDN := data.GIDNumberCounterDN.ValueString()
+	if gidNumberCounterDN == "" {
+		gidNumberCounterDN = os.Getenv("AD_GID_NUMBER_COUNTER_DN")
+	}
+
+	privilegedWritesEnabled := data.PrivilegedWritesEnabled.ValueBool()
+	if os.Getenv("AD_PRIVILEGED_WRITES_ENABLED") == "true" {
+		privilegedWritesEnabled = true
+	}
+
+	var privilegedGroups []string
+	if !data.PrivilegedGroups.IsNull() {
+		resp.Diagnostics.Append(data.PrivilegedGroups.ElementsAs(ctx, &privilegedGroups, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	deletionMode := data.DeletionMode.ValueString()
+	if deletionMode == "" {
+		deletionMode = os.Getenv("AD_DELETION_MODE")
+	}
+	if deletionMode == "" {
+		deletionMode = client.DeletionModeDelete
+	}
+	if deletionMode != client.DeletionModeDelete && deletionMode != client.DeletionModeQuarantine {
+		resp.Diagnostics.AddError(
+			"Invalid Deletion Mode Configuration",
+			fmt.Sprintf("deletion_mode must be %q or %q, got: %q", client.DeletionModeDelete, client.DeletionModeQuarantine, deletionMode),
+		)
+		return
+	}
+
+	quarantineOU := data.QuarantineOU.ValueString()
+	if quarantineOU == "" {
+		quarantineOU = os.Getenv("AD_QUARANTINE_OU")
+	}
+	if deletionMode == client.DeletionModeQuarantine && quarantineOU == "" {
+		resp.Diagnostics.AddError(
+			"Missing Quarantine OU Configuration",
+			"deletion_mode \"quarantine\" requires a quarantine OU. "+
+				"Set the quarantine_ou value in the configuration or use the AD_QUARANTINE_OU environment variable.",
+		)
+		return
+	}
+
 	// Create client
 	config := &client.ClientConfig{
 		Server:   server,
@@ -177,6 +296,18 @@ func (p *ADGroupsProvider) Configure(ctx context.Context, req provider.Configure
 		Password: password,
 		UseTLS:   useTLS,
 		Insecure: insecure,
+
+		GIDNumberMin:       gidNumberMin,
+		GIDNumberMax:       gidNumberMax,
+		GIDNumberCounterDN: gidNumberCounterDN,
+
+		GlobalCatalogServer: globalCatalogServer,
+
+		PrivilegedWritesEnabled: privilegedWritesEnabled,
+		PrivilegedGroups:        privilegedGroups,
+
+		DeletionMode: deletionMode,
+		QuarantineOU: quarantineOU,
 	}
 
 	adClient, err := client.NewClient(config)
@@ -194,10 +325,42 @@ func (p *ADGroupsProvider) Configure(ctx context.Context, req provider.Configure
 	resp.ResourceData = adClient
 }
 
+// int64Setting returns an integer provider setting, falling back to the
+// given environment variable. It returns false if the variable is not a
+// valid integer.
+func int64Setting(value types.Int64, env string, diags *diag.Diagnostics) (int64, bool) {
+	if !value.IsNull() {
+		return value.ValueInt64(), true
+	}
+
+	raw := os.Getenv(env)
+	if raw == "" {
+		return 0, true
+	}
+
+	parsed, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		diags.AddError(
+			"Invalid Provider Configuration",
+			fmt.Sprintf("The %s environment variable must be an integer, got: %q", env, raw),
+		)
+		return 0, false
+	}
+
+	return parsed, true
+}
+
 func (p *ADGroupsProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewGroupResource,
 		NewGroupMembershipResource,
+		NewOrganizationalUnitResource,
+		NewUserResource,
+		NewContactResource,
+		NewComputerResource,
+		NewManagedServiceAccountResource,
+		NewPasswordSettingsObjectResource,
+		NewAccessRuleResource,
 	}
 }
 
@@ -206,6 +369,12 @@ func (p *ADGroupsProvider) DataSources(ctx context.Context) []func() datasource.
 		NewGroupDataSource,
 		NewGroupsDataSource,
 		NewUserDataSource,
+		NewUsersDataSource,
+		NewComputerDataSource,
+		NewGroupTransitiveMembersDataSource,
+		NewUserTransitiveGroupsDataSource,
+		NewOrganizationalUnitsDataSource,
+		NewQuarantinedGroupsDataSource,
 	}
 }
 
@@ -0,0 +1,636 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AccessRuleResource{}
+var _ resource.ResourceWithImportState = &AccessRuleResource{}
+var _ resource.ResourceWithValidateConfig = &AccessRuleResource{}
+var _ resource.ResourceWithModifyPlan = &AccessRuleResource{}
+
+// accessRights maps the names accepted in 'rights' to access mask bits
+var accessRights = map[string]uint32{
+	"create_child":   client.RightDSCreateChild,
+	"delete_child":   client.RightDSDeleteChild,
+	"list_children":  client.RightDSListChildren,
+	"self":           client.RightDSSelf,
+	"read_property":  client.RightDSReadProperty,
+	"write_property": client.RightDSWriteProperty,
+	"delete_tree":    client.RightDSDeleteTree,
+	"list_object":    client.RightDSListObject,
+	"control_access": client.RightDSControlAccess,
+	"delete":         client.RightDelete,
+	"read_control":   client.RightReadControl,
+	"write_dac":      client.RightWriteDAC,
+	"write_owner":    client.RightWriteOwner,
+	"generic_read":   client.RightGenericRead,
+	"generic_write":  client.RightGenericWrite,
+	"generic_all":    client.RightGenericAll,
+}
+
+// accessRuleInheritance maps the names accepted in 'inheritance' to ACE
+// flags, following ActiveDirectorySecurityInheritance
+var accessRuleInheritance = map[string]byte{
+	"none":              0,
+	"all":               client.ACEFlagContainerInherit,
+	"descendents":       client.ACEFlagContainerInherit | client.ACEFlagInheritOnly,
+	"self_and_children": client.ACEFlagContainerInherit | client.ACEFlagNoPropagate,
+	"children":          client.ACEFlagContainerInherit | client.ACEFlagNoPropagate | client.ACEFlagInheritOnly,
+}
+
+func NewAccessRuleResource() resource.Resource {
+	return &AccessRuleResource{}
+}
+
+// AccessRuleResource defines the resource implementation.
+type AccessRuleResource struct {
+	client *client.Client
+}
+
+// AccessRuleResourceModel describes the resource data model.
+type AccessRuleResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	TargetDN             types.String `tfsdk:"target_dn"`
+	TargetGUID           types.String `tfsdk:"target_guid"`
+	Trustee              types.String `tfsdk:"trustee"`
+	TrusteeSID           types.String `tfsdk:"trustee_sid"`
+	AccessType           types.String `tfsdk:"access_type"`
+	Rights               types.Set    `tfsdk:"rights"`
+	ObjectType           types.String `tfsdk:"object_type"`
+	InheritedObjectType  types.String `tfsdk:"inherited_object_type"`
+	Inheritance          types.String `tfsdk:"inheritance"`
+	SDDL                 types.String `tfsdk:"sddl"`
+	AllowPrivilegedGroup types.Bool   `tfsdk:"allow_privileged_group"`
+}
+
+func (r *AccessRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_rule"
+}
+
+func (r *AccessRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	rightNames := make([]string, 0, len(accessRights))
+	for name := range accessRights {
+		rightNames = append(rightNames, "`"+name+"`")
+	}
+	sort.Strings(rightNames)
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a single explicit access control entry (ACE) in the DACL of an object, e.g. to delegate membership management of the groups in an OU. Other ACEs on the object are left untouched. Every change replaces the ACE, except for spelling the same target, rights or object types differently.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the target and the SDDL of the ACE, separated by '/'.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"target_dn": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Distinguished Name of the object (e.g. an OU or group) whose DACL holds the ACE. A DN that names the same object, e.g. after the target was renamed, doesn't replace the ACE.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(r.targetChanged, "Replaces the ACE if the target DN names another object.", "Replaces the ACE if the target DN names another object."),
+				},
+			},
+			"target_guid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the target, so the ACE is still found after the target is renamed or moved.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"trustee": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Distinguished Name or SID of the security principal the ACE applies to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"trustee_sid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SID of the trustee.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"access_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("allow"),
+				MarkdownDescription: "Whether the ACE allows or denies the rights: `allow` or `deny`. Defaults to `allow`.",
+				Validators: []validator.String{
+					stringOneOf("allow", "deny"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rights": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Rights granted or denied. One or more of " + strings.Join(rightNames, ", ") + ". Spellings that add up to the same access mask, e.g. `generic_read` and the rights it is made of, don't replace the ACE.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplaceIf(accessMaskChanged, "Replaces the ACE if the access mask changes.", "Replaces the ACE if the access mask changes."),
+				},
+			},
+			"object_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Attribute, class, extended right or property set the ACE is limited to, by lDAPDisplayName or name (e.g. `member`, `group`, `Reset-Password`) or GUID. With `create_child` or `delete_child`, a class limits the kind of child objects.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(r.objectTypeChanged, "Replaces the ACE if the object type resolves to another GUID.", "Replaces the ACE if the object type resolves to another GUID."),
+				},
+			},
+			"inherited_object_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Class of the descendant objects that inherit the ACE (e.g. `group`), by lDAPDisplayName or schemaIDGUID.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(r.objectTypeChanged, "Replaces the ACE if the object type resolves to another GUID.", "Replaces the ACE if the object type resolves to another GUID."),
+				},
+			},
+			"inheritance": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("none"),
+				MarkdownDescription: "Which objects the ACE applies to: `none` (the target only), `all` (the target and all descendants), `descendents` (all descendants only), `self_and_children` (the target and its direct children) or `children` (direct children only). Defaults to `none`.",
+				Validators: []validator.String{
+					stringOneOf("none", "all", "descendents", "self_and_children", "children"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sddl": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ACE in SDDL form, with object types and trustee resolved.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"allow_privileged_group": allowPrivilegedGroupAttribute,
+		},
+	}
+}
+
+func (r *AccessRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AccessRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var rights types.Set
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rights"), &rights)...)
+
+	if resp.Diagnostics.HasError() || rights.IsNull() || rights.IsUnknown() {
+		return
+	}
+
+	for _, element := range rights.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() {
+			continue
+		}
+		if _, ok := accessRights[value.ValueString()]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rights"),
+				"Invalid Access Right",
+				fmt.Sprintf("Unknown right %q.", value.ValueString()),
+			)
+		}
+	}
+
+	if len(rights.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rights"),
+			"Invalid Access Rights",
+			"At least one right is required.",
+		)
+	}
+}
+
+func (r *AccessRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Every change that touches the DACL replaces the ACE, so adding or
+	// removing an ACE on a privileged group needs an explicit opt-in
+	if r.client == nil || req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	var data AccessRuleResourceModel
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	} else {
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An unknown target DN is checked in Create instead
+	if !data.TargetDN.IsUnknown() {
+		checkPrivilegedGroup(r.client, data.TargetDN.ValueString(), data.AllowPrivilegedGroup, &resp.Diagnostics)
+	}
+}
+
+func (r *AccessRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AccessRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	targetGUID, err := r.client.GetObjectGUID(data.TargetDN.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("target_dn"), "Invalid Target", fmt.Sprintf("Unable to find the target object: %s", err))
+		return
+	}
+
+	// target_dn may have been unknown during planning, so check again now
+	// that it is resolved
+	checkPrivilegedGroup(r.client, data.TargetDN.ValueString(), data.AllowPrivilegedGroup, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	trusteeSID := data.Trustee.ValueString()
+	if !strings.HasPrefix(strings.ToUpper(trusteeSID), "S-") {
+		if trusteeSID, err = r.client.GetObjectSID(trusteeSID); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("trustee"), "Invalid Trustee", fmt.Sprintf("Unable to resolve the trustee: %s", err))
+			return
+		}
+	} else if _, err := client.ParseSID(trusteeSID); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("trustee"), "Invalid Trustee", err.Error())
+		return
+	}
+
+	var objectTypes [2]string
+	for i, attribute := range []struct {
+		name  string
+		value types.String
+	}{
+		{"object_type", data.ObjectType},
+		{"inherited_object_type", data.InheritedObjectType},
+	} {
+		if attribute.value.IsNull() {
+			continue
+		}
+		if objectTypes[i], err = r.client.ResolveObjectType(attribute.value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute.name), "Invalid Object Type", err.Error())
+			return
+		}
+	}
+
+	var rights []string
+	resp.Diagnostics.Append(data.Rights.ElementsAs(ctx, &rights, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ace := &client.ACE{
+		Type:                client.ACETypeAccessAllowed,
+		Flags:               accessRuleInheritance[data.Inheritance.ValueString()],
+		ObjectType:          objectTypes[0],
+		InheritedObjectType: objectTypes[1],
+		Mask:                accessMask(rights),
+		SID:                 trusteeSID,
+	}
+
+	deny := data.AccessType.ValueString() == "deny"
+	object := ace.ObjectType != "" || ace.InheritedObjectType != ""
+	switch {
+	case deny && object:
+		ace.Type = client.ACETypeAccessDeniedObject
+	case deny:
+		ace.Type = client.ACETypeAccessDenied
+	case object:
+		ace.Type = client.ACETypeAccessAllowedObject
+	}
+
+	if err := r.client.AddACE(client.GUIDReference(targetGUID), ace); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add access rule, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(targetGUID + "/" + ace.SDDL())
+	data.TargetGUID = types.StringValue(targetGUID)
+	data.TrusteeSID = types.StringValue(trusteeSID)
+	data.SDDL = types.StringValue(ace.SDDL())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccessRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AccessRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ace, err := client.ParseACESDDL(data.SDDL.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid State", fmt.Sprintf("Unable to parse the stored ACE: %s", err))
+		return
+	}
+
+	// A renamed or moved target shows up as a change of target_dn
+	targetDN, err := r.client.GetDNByGUID(data.TargetGUID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			// Target was deleted outside of Terraform
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access rule target, got error: %s", err))
+		return
+	}
+	data.TargetDN = dnValueLike(data.TargetDN, targetDN)
+
+	if data.AllowPrivilegedGroup.IsNull() {
+		data.AllowPrivilegedGroup = types.BoolValue(false)
+	}
+
+	found, err := r.client.HasACE(client.GUIDReference(data.TargetGUID.ValueString()), ace)
+	if err != nil {
+		if client.IsNotFound(err) {
+			// Target was deleted outside of Terraform
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access rule, got error: %s", err))
+		return
+	}
+
+	if !found {
+		// ACE was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccessRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AccessRuleResourceModel
+	var state AccessRuleResourceModel
+
+	// Changes that don't replace the ACE only respell the target, rights or
+	// object types, so the ACE stays as it is and the configured spelling is
+	// stored
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = state.ID
+	data.TargetGUID = state.TargetGUID
+	data.TrusteeSID = state.TrusteeSID
+	data.SDDL = state.SDDL
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccessRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AccessRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ace, err := client.ParseACESDDL(data.SDDL.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid State", fmt.Sprintf("Unable to parse the stored ACE: %s", err))
+		return
+	}
+
+	err = r.client.RemoveACE(client.GUIDReference(data.TargetGUID.ValueString()), ace)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove access rule, got error: %s", err))
+		return
+	}
+}
+
+func (r *AccessRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by "<target DN>|<ACE in SDDL form>"
+	targetDN, sddl, ok := strings.Cut(req.ID, "|")
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected \"<target DN>|<ACE in SDDL form>\", got: %q", req.ID),
+		)
+		return
+	}
+
+	ace, err := client.ParseACESDDL(sddl)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	inheritance := ""
+	for name, flags := range accessRuleInheritance {
+		if ace.Flags == flags {
+			inheritance = name
+		}
+	}
+	if inheritance == "" {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("The ACE flags of %s can't be expressed with 'inheritance'.", sddl))
+		return
+	}
+
+	targetGUID, err := r.client.GetObjectGUID(targetDN)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Failed", fmt.Sprintf("Unable to find the target object: %s", err))
+		return
+	}
+
+	found, err := r.client.HasACE(client.GUIDReference(targetGUID), ace)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Failed", fmt.Sprintf("Unable to read the security descriptor of the target: %s", err))
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("Import Failed", fmt.Sprintf("The target has no explicit ACE %s.", ace.SDDL()))
+		return
+	}
+
+	// Prefer the trustee's DN, as it would usually be configured
+	trustee := ace.SID
+	if dn, err := r.client.GetDNBySID(ace.SID); err == nil {
+		trustee = dn
+	}
+
+	rights, diags := types.SetValueFrom(ctx, types.StringType, accessRightNames(ace.Mask))
+	resp.Diagnostics.Append(diags...)
+
+	accessType := "allow"
+	if ace.IsDeny() {
+		accessType = "deny"
+	}
+
+	// Prefer names over GUIDs, as they would usually be configured
+	objectTypes := [2]string{ace.ObjectType, ace.InheritedObjectType}
+	for i, guid := range objectTypes {
+		if guid == "" {
+			continue
+		}
+		if name, err := r.client.ObjectTypeName(guid); err == nil {
+			objectTypes[i] = name
+		}
+	}
+
+	data := AccessRuleResourceModel{
+		ID:                   types.StringValue(targetGUID + "/" + ace.SDDL()),
+		TargetDN:             types.StringValue(targetDN),
+		TargetGUID:           types.StringValue(targetGUID),
+		Trustee:              types.StringValue(trustee),
+		TrusteeSID:           types.StringValue(ace.SID),
+		AccessType:           types.StringValue(accessType),
+		Rights:               rights,
+		ObjectType:           stringValueOrNull(objectTypes[0]),
+		InheritedObjectType:  stringValueOrNull(objectTypes[1]),
+		Inheritance:          types.StringValue(inheritance),
+		SDDL:                 types.StringValue(ace.SDDL()),
+		AllowPrivilegedGroup: types.BoolValue(false),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// accessMaskChanged replaces the ACE unless the planned and prior rights add
+// up to the same access mask.
+func accessMaskChanged(ctx context.Context, req planmodifier.SetRequest, resp *setplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.PlanValue.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	var planned, prior []string
+	resp.Diagnostics.Append(req.PlanValue.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(req.StateValue.ElementsAs(ctx, &prior, false)...)
+
+	resp.RequiresReplace = resp.Diagnostics.HasError() || accessMask(planned) != accessMask(prior)
+}
+
+// accessMask returns the access mask for the names of rights
+func accessMask(rights []string) uint32 {
+	var mask uint32
+	for _, right := range rights {
+		mask |= accessRights[right]
+	}
+	return mask
+}
+
+// targetChanged replaces the ACE unless the planned target DN names the
+// object the ACE is on. A planned DN that names no object fails the plan
+// rather than removing the ACE and then failing to add it again.
+func (r *AccessRuleResource) targetChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = true
+	if r.client == nil || req.PlanValue.IsUnknown() || req.StateValue.IsNull() {
+		return
+	}
+
+	var targetGUID types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("target_guid"), &targetGUID)...)
+	if resp.Diagnostics.HasError() || targetGUID.ValueString() == "" {
+		return
+	}
+
+	planned, err := r.client.GetObjectGUID(req.PlanValue.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Target Not Found",
+				fmt.Sprintf("No object has the DN %s. The ACE's target is now %s; update 'target_dn' to match.", req.PlanValue.ValueString(), req.StateValue.ValueString()),
+			)
+		}
+		return
+	}
+
+	resp.RequiresReplace = !strings.EqualFold(planned, targetGUID.ValueString())
+}
+
+// objectTypeChanged replaces the ACE unless the planned and prior object
+// type resolve to the same GUID, e.g. `member` and the GUID an import stored.
+func (r *AccessRuleResource) objectTypeChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = true
+	if r.client == nil || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() || req.StateValue.IsNull() {
+		return
+	}
+
+	planned, err := r.client.ResolveObjectType(req.PlanValue.ValueString())
+	if err != nil {
+		return
+	}
+	prior, err := r.client.ResolveObjectType(req.StateValue.ValueString())
+	if err != nil {
+		return
+	}
+
+	resp.RequiresReplace = !strings.EqualFold(planned, prior)
+}
+
+// accessRightNames returns the names of the rights in an access mask.
+// Full control is returned as generic_all; other masks are split into
+// individual rights.
+func accessRightNames(mask uint32) []string {
+	if mask == client.RightGenericAll {
+		return []string{"generic_all"}
+	}
+
+	var names []string
+	for name, right := range accessRights {
+		if strings.HasPrefix(name, "generic_") {
+			continue
+		}
+		if mask&right != 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,379 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ComputerResource{}
+var _ resource.ResourceWithImportState = &ComputerResource{}
+var _ resource.ResourceWithModifyPlan = &ComputerResource{}
+
+func NewComputerResource() resource.Resource {
+	return &ComputerResource{}
+}
+
+// ComputerResource defines the resource implementation.
+type ComputerResource struct {
+	client *client.Client
+}
+
+// ComputerResourceModel describes the resource data model.
+type ComputerResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	DN             types.String `tfsdk:"dn"`
+	Name           types.String `tfsdk:"name"`
+	OU             types.String `tfsdk:"ou"`
+	SamAccountName types.String `tfsdk:"sam_account_name"`
+	DNSHostName    types.String `tfsdk:"dns_host_name"`
+	Description    types.String `tfsdk:"description"`
+	ManagedBy      types.String `tfsdk:"managed_by"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	ObjectGUID     types.String `tfsdk:"object_guid"`
+	ObjectSid      types.String `tfsdk:"object_sid"`
+}
+
+func (r *ComputerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_computer"
+}
+
+func (r *ComputerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a pre-staged Active Directory computer account. The computer is tracked by its objectGUID, so renames and moves are applied in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the computer.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Distinguished Name of the computer, for use in `adgroups_group_membership`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name (cn) of the computer. Changing it renames the computer object in place.",
+			},
+			"ou": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Organizational Unit containing the computer (e.g., 'OU=Servers,DC=example,DC=com'). Changing it moves the computer in place.",
+			},
+			"sam_account_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "SAM account name of the computer, with or without the trailing '$'. Defaults to the upper-cased name on creation.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dns_host_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "DNS host name of the computer (dNSHostName).",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description of the computer.",
+			},
+			"managed_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Distinguished Name of the user or group managing the computer.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the computer account is enabled, through userAccountControl. Defaults to true.",
+			},
+			"object_guid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the computer.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"object_sid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectSid of the computer.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ComputerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ComputerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planRenamedDN(ctx, req, resp, "name", "ou")
+}
+
+func (r *ComputerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ComputerResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	samAccountName := data.SamAccountName.ValueString()
+	if data.SamAccountName.IsUnknown() || data.SamAccountName.IsNull() {
+		samAccountName = strings.ToUpper(data.Name.ValueString())
+	}
+
+	attributes := make(map[string][]string)
+	for name, value := range computerLDAPAttributes(data) {
+		if !value.IsNull() {
+			attributes[name] = []string{value.ValueString()}
+		}
+	}
+
+	dn, err := r.client.CreateComputer(
+		data.OU.ValueString(),
+		data.Name.ValueString(),
+		samAccountName,
+		data.Enabled.ValueBool(),
+		attributes,
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create computer, got error: %s", err))
+		return
+	}
+
+	computer, err := r.client.GetComputer(dn)
+	if err != nil {
+		abortCreatedObject("computer", dn, err, r.client.DeleteComputer, &resp.Diagnostics)
+		return
+	}
+
+	data.SamAccountName = types.StringValue(samAccountName)
+
+	// Map response body to schema and populate Computed attribute values
+	updateComputerResourceModel(&data, computer)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ComputerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ComputerResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	computer, err := r.client.GetComputerByGUID(data.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			// Computer was deleted outside of Terraform
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read computer, got error: %s", err))
+		return
+	}
+
+	updateComputerResourceModel(&data, computer)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ComputerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ComputerResourceModel
+	var state ComputerResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dn := state.DN.ValueString()
+
+	// Rename and/or move in place
+	if !data.Name.Equal(state.Name) || !client.DNEqual(data.OU.ValueString(), state.OU.ValueString()) {
+		newDN, err := r.client.RenameObject(dn, "CN="+client.EscapeDN(data.Name.ValueString()), data.OU.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rename or move computer, got error: %s", err))
+			return
+		}
+		dn = newDN
+	}
+
+	// Prepare updates
+	updates := make(map[string][]string)
+	current := computerLDAPAttributes(state)
+	for name, value := range computerLDAPAttributes(data) {
+		if value.Equal(current[name]) {
+			continue
+		}
+		if value.IsNull() {
+			updates[name] = []string{}
+		} else {
+			updates[name] = []string{value.ValueString()}
+		}
+	}
+
+	if !data.SamAccountName.IsUnknown() && !sameComputerSAMAccountName(data.SamAccountName.ValueString(), state.SamAccountName.ValueString()) {
+		updates["sAMAccountName"] = []string{client.ComputerSAMAccountName(data.SamAccountName.ValueString())}
+	}
+
+	if len(updates) > 0 {
+		if err := r.client.UpdateComputer(dn, updates); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update computer, got error: %s", err))
+			return
+		}
+	}
+
+	if !data.Enabled.Equal(state.Enabled) {
+		var set, clear int
+		if data.Enabled.ValueBool() {
+			clear = client.UACAccountDisable
+		} else {
+			set = client.UACAccountDisable
+		}
+		if err := r.client.SetUserAccountControl(dn, set, clear); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update userAccountControl, got error: %s", err))
+			return
+		}
+	}
+
+	computer, err := r.client.GetComputerByGUID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read computer after update, got error: %s", err))
+		return
+	}
+
+	if data.SamAccountName.IsUnknown() {
+		data.SamAccountName = state.SamAccountName
+	}
+
+	updateComputerResourceModel(&data, computer)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ComputerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ComputerResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteComputer(client.GUIDReference(data.ID.ValueString()))
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete computer, got error: %s", err))
+		return
+	}
+}
+
+func (r *ComputerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by objectGUID, DN, DNS host name or SAM account name
+	var computer *client.Computer
+	var err error
+
+	switch {
+	case isGUID(req.ID):
+		computer, err = r.client.GetComputerByGUID(req.ID)
+	case strings.Contains(req.ID, "="):
+		computer, err = r.client.GetComputer(req.ID)
+	case strings.Contains(req.ID, "."):
+		computer, err = r.client.GetComputerByDNSHostName(req.ID)
+	default:
+		computer, err = r.client.GetComputerBySAM(req.ID)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Import Failed",
+			fmt.Sprintf("Unable to find computer %q by objectGUID, DN, DNS host name or SAM account name: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), computer.ObjectGUID)...)
+}
+
+// computerLDAPAttributes maps the optional string arguments of the computer
+// resource to their LDAP attributes.
+func computerLDAPAttributes(data ComputerResourceModel) map[string]types.String {
+	return map[string]types.String{
+		"dNSHostName": data.DNSHostName,
+		"description": data.Description,
+		"managedBy":   data.ManagedBy,
+	}
+}
+
+// sameComputerSAMAccountName reports whether two computer SAM account names
+// are the same, ignoring case and the trailing "$".
+func sameComputerSAMAccountName(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "$"), strings.TrimSuffix(b, "$"))
+}
+
+// updateComputerResourceModel maps a computer read from AD onto the
+// resource model.
+func updateComputerResourceModel(data *ComputerResourceModel, computer *client.Computer) {
+	data.ID = types.StringValue(computer.ObjectGUID)
+	data.DN = types.StringValue(computer.DN)
+	data.Name = types.StringValue(computer.CN)
+	data.DNSHostName = stringValueOrNull(computer.DNSHostName)
+	data.Description = stringValueOrNull(computer.Description)
+	data.Enabled = types.BoolValue(computer.Enabled)
+	data.ObjectGUID = types.StringValue(computer.ObjectGUID)
+	data.ObjectSid = types.StringValue(computer.ObjectSid)
+
+	// Keep the configured form of the SAM account name, with or without
+	// the trailing "$"
+	if !sameComputerSAMAccountName(data.SamAccountName.ValueString(), computer.SamAccountName) {
+		data.SamAccountName = types.StringValue(strings.TrimSuffix(computer.SamAccountName, "$"))
+	}
+
+	data.ManagedBy = dnValueLike(data.ManagedBy, computer.ManagedBy)
+	data.OU = parentDNLike(data.OU, computer.DN)
+}
@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ContactResource{}
+var _ resource.ResourceWithImportState = &ContactResource{}
+var _ resource.ResourceWithModifyPlan = &ContactResource{}
+
+func NewContactResource() resource.Resource {
+	return &ContactResource{}
+}
+
+// ContactResource defines the resource implementation.
+type ContactResource struct {
+	client *client.Client
+}
+
+// ContactResourceModel describes the resource data model.
+type ContactResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	DN            types.String `tfsdk:"dn"`
+	Name          types.String `tfsdk:"name"`
+	OU            types.String `tfsdk:"ou"`
+	Email         types.String `tfsdk:"email"`
+	TargetAddress types.String `tfsdk:"target_address"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	Description   types.String `tfsdk:"description"`
+	ObjectGUID    types.String `tfsdk:"object_guid"`
+}
+
+func (r *ContactResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_contact"
+}
+
+func (r *ContactResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an Active Directory contact, e.g. an external member of a distribution group. The contact is tracked by its objectGUID, so renames and moves are applied in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the contact.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Distinguished Name of the contact, for use in `adgroups_group_membership`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name (cn) of the contact. Changing it renames the contact in place.",
+			},
+			"ou": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Organizational Unit containing the contact (e.g., 'OU=Contacts,DC=example,DC=com'). Changing it moves the contact in place.",
+			},
+			"email": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Email address of the contact (mail).",
+			},
+			"target_address": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Address mail to the contact is routed to (targetAddress), e.g. 'SMTP:partner@example.org'.",
+			},
+			"display_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Display name of the contact.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description of the contact.",
+			},
+			"object_guid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the contact.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ContactResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ContactResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planRenamedDN(ctx, req, resp, "name", "ou")
+}
+
+func (r *ContactResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ContactResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attributes := make(map[string][]string)
+	for name, value := range contactLDAPAttributes(data) {
+		if !value.IsNull() {
+			attributes[name] = []string{value.ValueString()}
+		}
+	}
+
+	dn, err := r.client.CreateContact(data.OU.ValueString(), data.Name.ValueString(), attributes)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create contact, got error: %s", err))
+		return
+	}
+
+	contact, err := r.client.GetContact(dn)
+	if err != nil {
+		abortCreatedObject("contact", dn, err, r.client.DeleteContact, &resp.Diagnostics)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	updateContactResourceModel(&data, contact)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContactResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ContactResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contact, err := r.client.GetContactByGUID(data.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			// Contact was deleted outside of Terraform
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read contact, got error: %s", err))
+		return
+	}
+
+	updateContactResourceModel(&data, contact)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContactResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ContactResourceModel
+	var state ContactResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dn := state.DN.ValueString()
+
+	// Rename and/or move in place
+	if !data.Name.Equal(state.Name) || !client.DNEqual(data.OU.ValueString(), state.OU.ValueString()) {
+		newDN, err := r.client.RenameObject(dn, "CN="+client.EscapeDN(data.Name.ValueString()), data.OU.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rename or move contact, got error: %s", err))
+			return
+		}
+		dn = newDN
+	}
+
+	// Prepare updates
+	updates := make(map[string][]string)
+	current := contactLDAPAttributes(state)
+	for name, value := range contactLDAPAttributes(data) {
+		if value.Equal(current[name]) {
+			continue
+		}
+		if value.IsNull() {
+			updates[name] = []string{}
+		} else {
+			updates[name] = []string{value.ValueString()}
+		}
+	}
+
+	if len(updates) > 0 {
+		if err := r.client.UpdateContact(dn, updates); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update contact, got error: %s", err))
+			return
+		}
+	}
+
+	contact, err := r.client.GetContactByGUID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read contact after update, got error: %s", err))
+		return
+	}
+
+	updateContactResourceModel(&data, contact)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContactResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ContactResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteContact(client.GUIDReference(data.ID.ValueString()))
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete contact, got error: %s", err))
+		return
+	}
+}
+
+func (r *ContactResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by objectGUID, DN or email address
+	var contact *client.Contact
+	var err error
+
+	switch {
+	case isGUID(req.ID):
+		contact, err = r.client.GetContactByGUID(req.ID)
+	case strings.Contains(req.ID, "="):
+		contact, err = r.client.GetContact(req.ID)
+	default:
+		contact, err = r.client.GetContactByEmail(req.ID)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Import Failed",
+			fmt.Sprintf("Unable to find contact %q by objectGUID, DN or email address: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), contact.ObjectGUID)...)
+}
+
+// contactLDAPAttributes maps the optional arguments of the contact resource
+// to their LDAP attributes.
+func contactLDAPAttributes(data ContactResourceModel) map[string]types.String {
+	return map[string]types.String{
+		"mail":          data.Email,
+		"targetAddress": data.TargetAddress,
+		"displayName":   data.DisplayName,
+		"description":   data.Description,
+	}
+}
+
+// updateContactResourceModel maps a contact read from AD onto the resource
+// model.
+func updateContactResourceModel(data *ContactResourceModel, contact *client.Contact) {
+	data.ID = types.StringValue(contact.ObjectGUID)
+	data.DN = types.StringValue(contact.DN)
+	data.Name = types.StringValue(contact.CN)
+	data.Email = stringValueOrNull(contact.Email)
+	data.TargetAddress = stringValueOrNull(contact.TargetAddress)
+	data.DisplayName = stringValueOrNull(contact.DisplayName)
+	data.Description = stringValueOrNull(contact.Description)
+	data.ObjectGUID = types.StringValue(contact.ObjectGUID)
+	data.OU = parentDNLike(data.OU, contact.DN)
+}
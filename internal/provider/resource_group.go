@@ -6,12 +6,15 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hknerts/terraform-provider-adgroups/internal/client"
 )
@@ -19,6 +22,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GroupResource{}
 var _ resource.ResourceWithImportState = &GroupResource{}
+var _ resource.ResourceWithValidateConfig = &GroupResource{}
+var _ resource.ResourceWithModifyPlan = &GroupResource{}
 
 func NewGroupResource() resource.Resource {
 	return &GroupResource{}
@@ -31,17 +36,28 @@ type GroupResource struct {
 
 // GroupResourceModel describes the resource data model.
 type GroupResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	DN             types.String `tfsdk:"dn"`
-	CN             types.String `tfsdk:"cn"`
-	Name           types.String `tfsdk:"name"`
-	SamAccountName types.String `tfsdk:"sam_account_name"`
-	Description    types.String `tfsdk:"description"`
-	GroupType      types.Int64  `tfsdk:"group_type"`
-	ManagedBy      types.String `tfsdk:"managed_by"`
-	OU             types.String `tfsdk:"ou"`
-	ObjectGUID     types.String `tfsdk:"object_guid"`
-	ObjectSid      types.String `tfsdk:"object_sid"`
+	ID                              types.String `tfsdk:"id"`
+	DN                              types.String `tfsdk:"dn"`
+	CN                              types.String `tfsdk:"cn"`
+	Name                            types.String `tfsdk:"name"`
+	SamAccountName                  types.String `tfsdk:"sam_account_name"`
+	Description                     types.String `tfsdk:"description"`
+	GroupType                       types.Int64  `tfsdk:"group_type"`
+	Scope                           types.String `tfsdk:"scope"`
+	Category                        types.String `tfsdk:"category"`
+	ManagedBy                       types.String `tfsdk:"managed_by"`
+	ManagerCanUpdateMembership      types.Bool   `tfsdk:"managed_by_can_update_membership"`
+	ProtectedFromAccidentalDeletion types.Bool   `tfsdk:"protected_from_accidental_deletion"`
+	Attributes                      types.Map    `tfsdk:"attributes"`
+	Email                           types.Object `tfsdk:"email"`
+	Posix                           types.Object `tfsdk:"posix"`
+	OU                              types.String `tfsdk:"ou"`
+	ObjectGUID                      types.String `tfsdk:"object_guid"`
+	ObjectSid                       types.String `tfsdk:"object_sid"`
+	AllowPrivilegedGroup            types.Bool   `tfsdk:"allow_privileged_group"`
+	ForceDestroy                    types.Bool   `tfsdk:"force_destroy"`
+	RestoreFromRecycleBin           types.Bool   `tfsdk:"restore_from_recycle_bin"`
+	RestoreObjectGUID               types.String `tfsdk:"restore_object_guid"`
 }
 
 func (r *GroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -95,15 +111,121 @@ func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"group_type": schema.Int64Attribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Group type. Common values: 2 (Global Distribution), 4 (Domain Local Distribution), 8 (Universal Distribution), -2147483646 (Global Security), -2147483644 (Domain Local Security), -2147483640 (Universal Security). Defaults to -2147483646 (Global Security).",
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.UseStateForUnknown(),
+				MarkdownDescription: "Raw groupType bitmask, computed from `scope` and `category`.",
+				DeprecationMessage:  "Use scope and category instead. Setting group_type directly will be removed in a future version; it remains available as a computed attribute.",
+			},
+			"scope": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Group scope: 'global', 'domain_local' or 'universal'. Defaults to 'global'.",
+				Validators: []validator.String{
+					stringOneOf(client.GroupScopeGlobal, client.GroupScopeDomainLocal, client.GroupScopeUniversal),
+				},
+			},
+			"category": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Group category: 'security' or 'distribution'. Defaults to 'security'.",
+				Validators: []validator.String{
+					stringOneOf(client.GroupCategorySecurity, client.GroupCategoryDistribution),
 				},
 			},
 			"managed_by": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Distinguished Name of the user or group that manages this group.",
 			},
+			"managed_by_can_update_membership": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Grant the `managed_by` principal write access to the group's members, like ADUC's \"Manager can update membership list\". The access is moved to the new manager when `managed_by` changes. Defaults to false.",
+			},
+			"protected_from_accidental_deletion": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Deny Everyone the right to delete the group, as ADUC's \"Protect object from accidental deletion\" does. The group cannot be destroyed while this is set. Defaults to false.",
+			},
+			"attributes": schema.MapAttribute{
+				ElementType:         extraAttributesType,
+				Optional:            true,
+				MarkdownDescription: "Additional LDAP attributes to manage on the group, keyed by LDAP display name (e.g., 'info', 'extensionAttribute1'). Only the listed attributes are managed; removing a key clears that attribute. Attributes are validated against the AD schema during planning.",
+			},
+			"email": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Mail settings of the group, for mail-enabled (e.g. distribution) groups. Addresses are checked for uniqueness across the forest during planning. Removing the block clears the mail attributes.",
+				Attributes: map[string]schema.Attribute{
+					"address": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Primary SMTP address. Sets 'mail' and the primary 'SMTP:' proxy address.",
+					},
+					"alias": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Exchange alias (mailNickname).",
+					},
+					"display_name": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Display name shown in address lists.",
+					},
+					"proxy_addresses": schema.SetAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Secondary proxy addresses including their type prefix, e.g. 'smtp:alias@example.com'. The primary address is added from 'address' and must not be listed.",
+					},
+					"hidden_from_address_lists": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+						MarkdownDescription: "Hide the group from address lists (msExchHideFromAddressLists). Defaults to false.",
+					},
+					"accept_messages_only_from": schema.SetAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Distinguished Names of senders allowed to send to the group (authOrig).",
+					},
+					"accept_messages_only_from_members_of": schema.SetAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Distinguished Names of groups whose members may send to the group (dLMemSubmitPerms).",
+					},
+					"moderation_enabled": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+						MarkdownDescription: "Require messages to the group to be approved by a moderator (msExchEnableModeration). Defaults to false.",
+					},
+					"moderated_by": schema.SetAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Distinguished Names of the moderators (msExchModeratedByLink).",
+					},
+				},
+			},
+			"posix": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "RFC 2307 settings for groups used by Linux hosts (e.g. through SSSD). Removing the block clears the attributes it managed.",
+				Attributes: map[string]schema.Attribute{
+					"gid_number": schema.Int64Attribute{
+						Optional:            true,
+						Computed:            true,
+						MarkdownDescription: "POSIX group ID (gidNumber). If not set, the next free number is allocated from the provider's 'gid_number_min'-'gid_number_max' range.",
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+						},
+					},
+					"sync_member_uid": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+						MarkdownDescription: "Keep memberUid in sync with the direct user members of the group, using their uid or sAMAccountName. Defaults to false.",
+					},
+					"member_uids": schema.SetAttribute{
+						ElementType:         types.StringType,
+						Computed:            true,
+						MarkdownDescription: "memberUid values of the group, when 'sync_member_uid' is enabled.",
+					},
+				},
+			},
 			"ou": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Organizational Unit where the group will be created (e.g., 'OU=Groups,DC=example,DC=com').",
@@ -111,6 +233,16 @@ func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"restore_from_recycle_bin": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "On create, restore the most recently deleted group with the same sAMAccountName from the AD Recycle Bin instead of creating a new one, keeping its SID so existing ACLs keep working. The restored group is moved to 'ou' and its settings are reconciled with the configuration. A new group is created if there is no such deleted group. Defaults to false.",
+			},
+			"restore_object_guid": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The objectGUID of the deleted group to restore with 'restore_from_recycle_bin', instead of the most recently deleted group with the same sAMAccountName. Creating the group fails if there is no such deleted group. Only used on create.",
+			},
 			"object_guid": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The objectGUID of the group.",
@@ -125,6 +257,13 @@ func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"allow_privileged_group": allowPrivilegedGroupAttribute,
+			"force_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Delete the group even if it still has members, is a member of other groups, manages other objects or has password settings objects applied to it. Without it, destroying a group that is still in use fails with a list of its references. Defaults to false.",
+			},
 		},
 	}
 }
@@ -149,6 +288,299 @@ func (r *GroupResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.client = client
 }
 
+func (r *GroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data GroupResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateGroupEmail(ctx, data.Email, &resp.Diagnostics)
+
+	if data.ManagerCanUpdateMembership.ValueBool() && data.ManagedBy.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("managed_by_can_update_membership"),
+			"Missing Manager",
+			"'managed_by_can_update_membership' requires 'managed_by' to be set.",
+		)
+	}
+
+	if !data.RestoreObjectGUID.IsNull() && !data.RestoreFromRecycleBin.IsUnknown() && !data.RestoreFromRecycleBin.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("restore_object_guid"),
+			"Missing Restore Option",
+			"'restore_object_guid' requires 'restore_from_recycle_bin' to be true.",
+		)
+	}
+
+	if !data.RestoreObjectGUID.IsNull() && !data.RestoreObjectGUID.IsUnknown() {
+		if _, err := client.ParseGUID(data.RestoreObjectGUID.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("restore_object_guid"), "Invalid GUID", err.Error())
+		}
+	}
+
+	if data.GroupType.IsNull() || data.GroupType.IsUnknown() {
+		return
+	}
+
+	if !data.Scope.IsNull() || !data.Category.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("group_type"),
+			"Conflicting Group Type Attributes",
+			"'group_type' cannot be combined with 'scope' or 'category'. Remove 'group_type' and use 'scope' and 'category' instead.",
+		)
+		return
+	}
+
+	_, _, builtin, err := client.DescribeGroupType(data.GroupType.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("group_type"), "Invalid Group Type", err.Error())
+		return
+	}
+
+	if builtin {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("group_type"),
+			"Invalid Group Type",
+			"The builtin-local flag (0x1) is reserved for groups in the Builtin container and cannot be set by Terraform.",
+		)
+	}
+}
+
+func (r *GroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Changes to privileged groups, including destroying them, need an
+	// explicit opt-in
+	if r.client != nil && !req.State.Raw.IsNull() && !req.Plan.Raw.Equal(req.State.Raw) {
+		var state GroupResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		allow := state.AllowPrivilegedGroup
+		if !req.Plan.Raw.IsNull() {
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_privileged_group"), &allow)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		checkPrivilegedGroup(r.client, state.DN.ValueString(), allow, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Nothing else to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config GroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state *GroupResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &GroupResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Validate the extra attributes against the AD schema
+	if r.client != nil {
+		validateExtraAttributes(ctx, r.client, config.Attributes, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r.checkMailConflicts(ctx, plan.Email, state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r.planPosix(ctx, &plan, state, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if config.Scope.IsUnknown() || config.Category.IsUnknown() || config.GroupType.IsUnknown() {
+		return
+	}
+
+	// Resolve the desired scope and category: explicit values win, then the
+	// deprecated group_type, then the current value, then the defaults
+	scope, category := client.GroupScopeGlobal, client.GroupCategorySecurity
+	if !config.GroupType.IsNull() {
+		var err error
+		scope, category, _, err = client.DescribeGroupType(config.GroupType.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("group_type"), "Invalid Group Type", err.Error())
+			return
+		}
+	} else if state != nil {
+		if stateScope, stateCategory, _, err := client.DescribeGroupType(state.GroupType.ValueInt64()); err == nil {
+			scope, category = stateScope, stateCategory
+		}
+	}
+	if !config.Scope.IsNull() {
+		scope = config.Scope.ValueString()
+	}
+	if !config.Category.IsNull() {
+		category = config.Category.ValueString()
+	}
+
+	groupType, err := client.ComputeGroupType(scope, category)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Group Type", err.Error())
+		return
+	}
+
+	// Builtin groups keep their builtin-local flag and can't be converted
+	if state != nil && state.GroupType.ValueInt64()&client.GroupTypeBuiltinLocal != 0 {
+		if scope != client.GroupScopeDomainLocal || category != client.GroupCategorySecurity {
+			resp.Diagnostics.AddError(
+				"Invalid Group Type",
+				"Builtin groups must remain domain local security groups; their scope and category cannot be changed.",
+			)
+			return
+		}
+		groupType |= client.GroupTypeBuiltinLocal
+	}
+
+	// Check the AD scope conversion rules before planning a scope change
+	if state != nil && !state.Scope.IsNull() && state.Scope.ValueString() != scope && r.client != nil {
+		r.checkScopeConversion(state.DN.ValueString(), state.Scope.ValueString(), scope, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("scope"), scope)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("category"), category)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("group_type"), groupType)...)
+}
+
+// checkMailConflicts reports SMTP addresses of the planned email settings
+// that are already used by another object in the forest. Addresses the group
+// already had are not checked again.
+func (r *GroupResource) checkMailConflicts(ctx context.Context, email types.Object, state *GroupResourceModel, diags *diag.Diagnostics) {
+	model, modelDiags := groupEmailFromObject(ctx, email)
+	diags.Append(modelDiags...)
+	planned, addressDiags := groupEmailSMTPAddresses(ctx, model)
+	diags.Append(addressDiags...)
+	if diags.HasError() || len(planned) == 0 {
+		return
+	}
+
+	existing := make(map[string]bool)
+	var excludeGUID string
+	if state != nil {
+		excludeGUID = state.ObjectGUID.ValueString()
+
+		stateModel, stateDiags := groupEmailFromObject(ctx, state.Email)
+		diags.Append(stateDiags...)
+		current, currentDiags := groupEmailSMTPAddresses(ctx, stateModel)
+		diags.Append(currentDiags...)
+		for _, address := range current {
+			existing[strings.ToLower(address)] = true
+		}
+	}
+
+	var added []string
+	for _, address := range planned {
+		if !existing[strings.ToLower(address)] {
+			added = append(added, address)
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	conflicts, forestWide, err := r.client.FindMailConflicts(added, excludeGUID)
+	if err != nil {
+		diags.AddAttributeError(path.Root("email"), "Client Error", fmt.Sprintf("Unable to check mail addresses for conflicts, got error: %s", err))
+		return
+	}
+	if !forestWide {
+		diags.AddAttributeWarning(
+			path.Root("email"),
+			"Mail Addresses Checked In This Domain Only",
+			"The global catalog could not be reached, so mail addresses were only checked for conflicts in this domain. "+
+				"Set 'global_catalog_server' on the provider to a global catalog to check the whole forest.",
+		)
+	}
+
+	for _, address := range added {
+		if dns, ok := conflicts[address]; ok {
+			diags.AddAttributeError(
+				path.Root("email"),
+				"Mail Address Already In Use",
+				fmt.Sprintf("The address %q is already used by:\n  - %s", address, strings.Join(dns, "\n  - ")),
+			)
+		}
+	}
+}
+
+// checkScopeConversion reports the groups that block converting the group
+// from one scope to another, including any intermediate universal step.
+func (r *GroupResource) checkScopeConversion(dn, from, to string, diags *diag.Diagnostics) {
+	steps := scopeConversionPath(from, to)
+	if len(steps) > 2 {
+		diags.AddAttributeWarning(
+			path.Root("scope"),
+			"Multi-step Group Scope Conversion",
+			fmt.Sprintf("Active Directory does not allow converting a group directly from %s to %s. "+
+				"The group will be converted in steps: %s.", from, to, strings.Join(steps, " -> ")),
+		)
+	}
+
+	for i := 1; i < len(steps); i++ {
+		blockers, err := r.client.ScopeConversionBlockers(dn, steps[i-1], steps[i])
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to check group scope conversion, got error: %s", err))
+			return
+		}
+
+		if len(blockers) > 0 {
+			diags.AddAttributeError(
+				path.Root("scope"),
+				"Group Scope Conversion Not Allowed",
+				fmt.Sprintf("The group cannot be converted from %s to %s scope because of the following groups:\n  - %s\n\n%s",
+					steps[i-1], steps[i], strings.Join(blockers, "\n  - "), scopeConversionRule(steps[i-1], steps[i])),
+			)
+		}
+	}
+}
+
+// scopeConversionPath returns every scope a group passes through when
+// converting from one scope to another, including the starting scope.
+func scopeConversionPath(from, to string) []string {
+	return append([]string{from}, client.GroupScopeConversionSteps(from, to)...)
+}
+
+// scopeConversionRule explains the AD rule for a single conversion step.
+func scopeConversionRule(from, to string) string {
+	switch {
+	case from == client.GroupScopeGlobal && to == client.GroupScopeUniversal:
+		return "A global group can only become universal if it is not a member of another global group."
+	case from == client.GroupScopeDomainLocal && to == client.GroupScopeUniversal:
+		return "A domain local group can only become universal if it has no domain local groups as members."
+	case from == client.GroupScopeUniversal && to == client.GroupScopeGlobal:
+		return "A universal group can only become global if it has no universal groups as members."
+	case from == client.GroupScopeUniversal && to == client.GroupScopeDomainLocal:
+		return "A universal group can only become domain local if it is not a member of another universal group."
+	}
+	return ""
+}
+
 func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data GroupResourceModel
 
@@ -159,65 +591,137 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// Set default group type if not specified
-	groupType := int(-2147483646) // Global Security Group
-	if !data.GroupType.IsNull() && !data.GroupType.IsUnknown() {
-		groupType = int(data.GroupType.ValueInt64())
+	// Build every attribute into the add request so the group is created in
+	// a single operation
+	attributes, diags := extraAttributesFromMap(ctx, data.Attributes)
+	resp.Diagnostics.Append(diags...)
+	emailAttributes, diags := groupEmailLDAPAttributes(ctx, data.Email)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for name, values := range emailAttributes {
+		attributes[name] = values
 	}
 
-	// Create the group
-	group, err := r.client.CreateGroup(
-		data.OU.ValueString(),
-		data.CN.ValueString(),
-		data.Description.ValueString(),
-		groupType,
-	)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create group, got error: %s", err))
+	// gidNumbers are reserved on the shared counter before they are written
+	posix, diags := groupPosixFromObject(ctx, data.Posix)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Update managedBy if specified
-	if !data.ManagedBy.IsNull() && !data.ManagedBy.IsUnknown() {
-		updates := map[string][]string{
-			"managedBy": {data.ManagedBy.ValueString()},
+	if posix != nil {
+		if posix.GIDNumber.IsUnknown() {
+			gid, err := r.client.AllocateGIDNumber()
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to allocate gidNumber, got error: %s", err))
+				return
+			}
+			attributes["gidNumber"] = []string{strconv.FormatInt(gid, 10)}
+
+			data.Posix, diags = setGIDNumber(ctx, posix, gid)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		} else if !posix.GIDNumber.IsNull() {
+			attributes["gidNumber"] = []string{strconv.FormatInt(posix.GIDNumber.ValueInt64(), 10)}
+		}
+	}
+
+	if !data.SamAccountName.IsNull() && !data.SamAccountName.IsUnknown() {
+		attributes["sAMAccountName"] = []string{data.SamAccountName.ValueString()}
+	}
+	if data.Description.ValueString() != "" {
+		attributes["description"] = []string{data.Description.ValueString()}
+	}
+	if data.ManagedBy.ValueString() != "" {
+		attributes["managedBy"] = []string{data.ManagedBy.ValueString()}
+	}
+
+	// Restoring a deleted group keeps its SID
+	var dn string
+	if data.RestoreFromRecycleBin.ValueBool() {
+		var err error
+		dn, err = r.restoreGroup(&data, attributes)
+		if err != nil {
+			if dn != "" {
+				r.abortCreate(ctx, dn, fmt.Errorf("unable to reconcile restored group: %w", err), resp)
+				return
+			}
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to restore group from the Recycle Bin, got error: %s", err))
+			return
 		}
-		err = r.client.UpdateGroup(group.DN, updates)
+	}
+
+	if dn == "" {
+		// The group type is computed from scope and category during planning
+		var err error
+		dn, err = r.client.CreateGroup(
+			data.OU.ValueString(),
+			data.CN.ValueString(),
+			int(data.GroupType.ValueInt64()),
+			attributes,
+		)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update group managedBy, got error: %s", err))
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create group, got error: %s", err))
 			return
 		}
-		
-		// Refresh group data
-		group, err = r.client.GetGroup(group.DN)
+	}
+
+	if data.ManagerCanUpdateMembership.ValueBool() {
+		err := r.client.SetManagerCanUpdateMembership(dn, data.ManagedBy.ValueString(), true)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group after update, got error: %s", err))
+			r.abortCreate(ctx, dn, fmt.Errorf("unable to grant the manager access to the members: %w", err), resp)
 			return
 		}
 	}
 
-	// Map response body to schema and populate Computed attribute values
-	data.ID = types.StringValue(group.DN)
-	data.DN = types.StringValue(group.DN)
-	data.CN = types.StringValue(group.CN)
-	data.Name = types.StringValue(group.Name)
-	data.SamAccountName = types.StringValue(group.SamAccountName)
-	data.Description = types.StringValue(group.Description)
-	
-	if group.GroupType != "" {
-		if groupTypeInt, err := strconv.ParseInt(group.GroupType, 10, 64); err == nil {
-			data.GroupType = types.Int64Value(groupTypeInt)
+	group, err := r.client.GetGroup(dn)
+	if err != nil {
+		r.abortCreate(ctx, dn, fmt.Errorf("unable to read group after creation: %w", err), resp)
+		return
+	}
+
+	// Protect last: once protected, abortCreate can no longer delete the group
+	if data.ProtectedFromAccidentalDeletion.ValueBool() {
+		if err := r.client.SetProtectedFromDeletion(dn, true); err != nil {
+			r.abortCreate(ctx, dn, fmt.Errorf("unable to protect group from accidental deletion: %w", err), resp)
+			return
 		}
 	}
-	
-	data.ManagedBy = types.StringValue(group.ManagedBy)
-	data.ObjectGUID = types.StringValue(group.ObjectGUID)
-	data.ObjectSid = types.StringValue(group.ObjectSid)
+
+	// Map response body to schema and populate Computed attribute values
+	updateGroupResourceModel(&data, group)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// abortCreate handles a failure after the group object was added: the group
+// is deleted again so the next apply can retry cleanly. If that fails too,
+// the group is adopted into state so Terraform marks it tainted and replaces
+// it, instead of failing with "already exists" on the next apply.
+func (r *GroupResource) abortCreate(ctx context.Context, dn string, cause error, resp *resource.CreateResponse) {
+	err := r.client.DeleteGroup(dn)
+	if err == nil {
+		resp.Diagnostics.AddError(
+			"Group Creation Failed",
+			fmt.Sprintf("The group %s was created but a follow-up step failed, so it was deleted again: %s", dn, cause),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dn)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dn"), dn)...)
+	resp.Diagnostics.AddError(
+		"Group Creation Failed",
+		fmt.Sprintf("The group %s was created but a follow-up step failed: %s\n\n"+
+			"Deleting the partially created group also failed (%s), so it has been saved to state and will be replaced on the next apply.", dn, cause, err),
+	)
+}
+
 func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data GroupResourceModel
 
@@ -241,22 +745,67 @@ func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Update the model with current values
-	data.ID = types.StringValue(group.DN)
-	data.DN = types.StringValue(group.DN)
-	data.CN = types.StringValue(group.CN)
-	data.Name = types.StringValue(group.Name)
-	data.SamAccountName = types.StringValue(group.SamAccountName)
-	data.Description = types.StringValue(group.Description)
-	
-	if group.GroupType != "" {
-		if groupTypeInt, err := strconv.ParseInt(group.GroupType, 10, 64); err == nil {
-			data.GroupType = types.Int64Value(groupTypeInt)
+	updateGroupResourceModel(&data, group)
+
+	canUpdate := false
+	if group.ManagedBy != "" {
+		canUpdate, err = r.client.ManagerCanUpdateMembership(group.DN, group.ManagedBy)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the manager's access to the group, got error: %s", err))
+			return
 		}
 	}
-	
-	data.ManagedBy = types.StringValue(group.ManagedBy)
-	data.ObjectGUID = types.StringValue(group.ObjectGUID)
-	data.ObjectSid = types.StringValue(group.ObjectSid)
+	data.ManagerCanUpdateMembership = types.BoolValue(canUpdate)
+
+	protected, err := r.client.IsProtectedFromDeletion(group.DN)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group protection, got error: %s", err))
+		return
+	}
+	data.ProtectedFromAccidentalDeletion = types.BoolValue(protected)
+
+	attributes, diags := readExtraAttributes(ctx, r.client, group.DN, data.Attributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Attributes = attributes
+
+	// Mail settings are only refreshed when managed
+	if !data.Email.IsNull() {
+		email, diags := groupEmailValue(ctx, group, data.Email)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Email = email
+	}
+
+	// RFC 2307 attributes are only refreshed when managed
+	if !data.Posix.IsNull() {
+		posix, diags := groupPosixFromObject(ctx, data.Posix)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Posix, diags = groupPosixValue(ctx, group, posix.SyncMemberUID.ValueBool())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Not stored in AD; default after import
+	if data.AllowPrivilegedGroup.IsNull() {
+		data.AllowPrivilegedGroup = types.BoolValue(false)
+	}
+	if data.ForceDestroy.IsNull() {
+		data.ForceDestroy = types.BoolValue(false)
+	}
+	if data.RestoreFromRecycleBin.IsNull() {
+		data.RestoreFromRecycleBin = types.BoolValue(false)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -274,27 +823,83 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Prepare updates
-	updates := make(map[string][]string)
+	// Prepare updates, starting with the extra attributes
+	updates, diags := extraAttributeUpdates(ctx, data.Attributes, state.Attributes)
+	resp.Diagnostics.Append(diags...)
+	emailUpdates, diags := groupEmailUpdates(ctx, data.Email, state.Email)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for name, values := range emailUpdates {
+		updates[name] = values
+	}
 
-	if !data.Description.Equal(state.Description) {
-		if data.Description.IsNull() {
+	planPosix, diags := groupPosixFromObject(ctx, data.Posix)
+	resp.Diagnostics.Append(diags...)
+	statePosix, diags := groupPosixFromObject(ctx, state.Posix)
+	resp.Diagnostics.Append(diags...)
+	posixUpdates, diags := groupPosixUpdates(ctx, planPosix, statePosix)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for name, values := range posixUpdates {
+		updates[name] = values
+	}
+
+	// gidNumbers are reserved on the shared counter before they are written
+	if planPosix != nil && planPosix.GIDNumber.IsUnknown() {
+		gid, err := r.client.AllocateGIDNumber()
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to allocate gidNumber, got error: %s", err))
+			return
+		}
+		updates["gidNumber"] = []string{strconv.FormatInt(gid, 10)}
+
+		data.Posix, diags = setGIDNumber(ctx, planPosix, gid)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if data.Description.ValueString() != state.Description.ValueString() {
+		if data.Description.ValueString() == "" {
 			updates["description"] = []string{}
 		} else {
 			updates["description"] = []string{data.Description.ValueString()}
 		}
 	}
 
-	if !data.ManagedBy.Equal(state.ManagedBy) {
-		if data.ManagedBy.IsNull() {
+	if data.ManagedBy.ValueString() != state.ManagedBy.ValueString() {
+		if data.ManagedBy.ValueString() == "" {
 			updates["managedBy"] = []string{}
 		} else {
 			updates["managedBy"] = []string{data.ManagedBy.ValueString()}
 		}
 	}
 
+	// The manager's access to the members follows managed_by: revoke it from
+	// the previous manager before it is replaced, grant it afterwards
+	managerChanged := data.ManagedBy.ValueString() != state.ManagedBy.ValueString()
+	if state.ManagerCanUpdateMembership.ValueBool() && state.ManagedBy.ValueString() != "" &&
+		(managerChanged || !data.ManagerCanUpdateMembership.ValueBool()) {
+		err := r.client.SetManagerCanUpdateMembership(data.DN.ValueString(), state.ManagedBy.ValueString(), false)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke the previous manager's access to the members, got error: %s", err))
+			return
+		}
+	}
+
+	// Change the group type first, converting through universal scope if
+	// AD doesn't allow a direct scope conversion
 	if !data.GroupType.Equal(state.GroupType) {
-		updates["groupType"] = []string{fmt.Sprintf("%d", data.GroupType.ValueInt64())}
+		err := r.client.SetGroupType(data.DN.ValueString(), state.GroupType.ValueInt64(), data.GroupType.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change group type, got error: %s", err))
+			return
+		}
 	}
 
 	// Apply updates if any
@@ -306,6 +911,21 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		}
 	}
 
+	if data.ManagerCanUpdateMembership.ValueBool() && (managerChanged || !state.ManagerCanUpdateMembership.ValueBool()) {
+		err := r.client.SetManagerCanUpdateMembership(data.DN.ValueString(), data.ManagedBy.ValueString(), true)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant the manager access to the members, got error: %s", err))
+			return
+		}
+	}
+
+	if !data.ProtectedFromAccidentalDeletion.Equal(state.ProtectedFromAccidentalDeletion) {
+		if err := r.client.SetProtectedFromDeletion(data.DN.ValueString(), data.ProtectedFromAccidentalDeletion.ValueBool()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change accidental deletion protection, got error: %s", err))
+			return
+		}
+	}
+
 	// Read the updated group
 	group, err := r.client.GetGroup(data.DN.ValueString())
 	if err != nil {
@@ -314,22 +934,7 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Update the model
-	data.ID = types.StringValue(group.DN)
-	data.DN = types.StringValue(group.DN)
-	data.CN = types.StringValue(group.CN)
-	data.Name = types.StringValue(group.Name)
-	data.SamAccountName = types.StringValue(group.SamAccountName)
-	data.Description = types.StringValue(group.Description)
-	
-	if group.GroupType != "" {
-		if groupTypeInt, err := strconv.ParseInt(group.GroupType, 10, 64); err == nil {
-			data.GroupType = types.Int64Value(groupTypeInt)
-		}
-	}
-	
-	data.ManagedBy = types.StringValue(group.ManagedBy)
-	data.ObjectGUID = types.StringValue(group.ObjectGUID)
-	data.ObjectSid = types.StringValue(group.ObjectSid)
+	updateGroupResourceModel(&data, group)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -345,8 +950,63 @@ func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	// Refuse to delete protected groups; the protection has to be lifted in
+	// a separate apply first
+	protected, err := r.client.IsProtectedFromDeletion(data.DN.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			// If group is already deleted, that's fine
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group protection, got error: %s", err))
+		return
+	}
+
+	if protected {
+		resp.Diagnostics.AddError(
+			"Group Is Protected",
+			fmt.Sprintf("The group %s is protected from accidental deletion. Set 'protected_from_accidental_deletion' to false and apply before destroying it.", data.DN.ValueString()),
+		)
+		return
+	}
+
+	// Refuse to delete groups that are still in use, listing what would be
+	// affected
+	if !data.ForceDestroy.ValueBool() {
+		refs, err := r.client.GetGroupReferences(data.DN.ValueString())
+		if err != nil {
+			if client.IsNotFound(err) {
+				return
+			}
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check references to group, got error: %s", err))
+			return
+		}
+
+		if !refs.Empty() {
+			resp.Diagnostics.AddError(
+				"Group Is In Use",
+				fmt.Sprintf("The group %s is still in use:\n%s\n"+
+					"Remove these references first, or set 'force_destroy' to true and apply to delete the group anyway.",
+					data.DN.ValueString(), groupReferencesSummary(refs)),
+			)
+			return
+		}
+	}
+
+	// In quarantine mode the group is kept, emptied, in the quarantine OU
+	if r.client.DeletionMode() == client.DeletionModeQuarantine {
+		// The resource address isn't visible to providers, so the group is
+		// identified by the resource type and its objectGUID
+		origin := fmt.Sprintf("adgroups_group with objectGUID %s", data.ObjectGUID.ValueString())
+		_, err := r.client.QuarantineGroup(data.DN.ValueString(), origin)
+		if err != nil && !client.IsNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to quarantine group, got error: %s", err))
+		}
+		return
+	}
+
 	// Delete the group
-	err := r.client.DeleteGroup(data.DN.ValueString())
+	err = r.client.DeleteGroup(data.DN.ValueString())
 	if err != nil {
 		if !strings.Contains(err.Error(), "not found") {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete group, got error: %s", err))
@@ -359,7 +1019,98 @@ func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 func (r *GroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Import by DN
 	resource.ImportStatePassthroughID(ctx, path.Root("dn"), req, resp)
-	
+
 	// Set ID to the same value as DN
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
+
+// updateGroupResourceModel maps a group read from AD onto the resource model.
+func updateGroupResourceModel(data *GroupResourceModel, group *client.Group) {
+	data.ID = types.StringValue(group.DN)
+	data.DN = types.StringValue(group.DN)
+	data.CN = types.StringValue(group.CN)
+	data.Name = types.StringValue(group.Name)
+	data.SamAccountName = types.StringValue(group.SamAccountName)
+	data.Description = stringValueLike(data.Description, group.Description)
+	data.GroupType, data.Scope, data.Category = groupTypeValues(group.GroupType)
+	data.ManagedBy = stringValueLike(data.ManagedBy, group.ManagedBy)
+	data.ObjectGUID = types.StringValue(group.ObjectGUID)
+	data.ObjectSid = types.StringValue(group.ObjectSid)
+}
+
+// maxListedReferences limits how many references of each kind are listed
+// when refusing to delete a group
+const maxListedReferences = 20
+
+// groupReferencesSummary itemizes the references to a group by kind.
+func groupReferencesSummary(refs *client.GroupReferences) string {
+	var summary strings.Builder
+	for _, kind := range []struct {
+		title string
+		dns   []string
+	}{
+		{"Members", refs.Members},
+		{"Member of", refs.MemberOf},
+		{"Manager (managedBy) of", refs.ManagedObjects},
+		{"Password settings objects applied to the group", refs.PasswordSettings},
+	} {
+		if len(kind.dns) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&summary, "\n%s (%d):\n", kind.title, len(kind.dns))
+		for i, dn := range kind.dns {
+			if i == maxListedReferences {
+				fmt.Fprintf(&summary, "  ... and %d more\n", len(kind.dns)-maxListedReferences)
+				break
+			}
+			fmt.Fprintf(&summary, "  - %s\n", dn)
+		}
+	}
+
+	return summary.String()
+}
+
+// groupTypeValues converts a raw groupType attribute value to the
+// group_type, scope and category attribute values.
+func groupTypeValues(raw string) (types.Int64, types.String, types.String) {
+	groupType, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return types.Int64Null(), types.StringNull(), types.StringNull()
+	}
+
+	scope, category, _, err := client.DescribeGroupType(groupType)
+	if err != nil {
+		return types.Int64Value(groupType), types.StringNull(), types.StringNull()
+	}
+
+	return types.Int64Value(groupType), types.StringValue(scope), types.StringValue(category)
+}
+
+// stringValueLike returns an LDAP attribute value as a string, keeping a
+// null prior value null while the attribute is empty. Configurations that
+// set "" and ones that omit the attribute both keep matching.
+func stringValueLike(prior types.String, value string) types.String {
+	if value == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// setValueLike returns LDAP attribute values as a set, keeping a null prior
+// value null while there are no values. A configured empty set stays empty.
+func setValueLike(ctx context.Context, prior types.Set, values []string) (types.Set, diag.Diagnostics) {
+	if len(values) == 0 && (prior.IsNull() || prior.IsUnknown()) {
+		return types.SetNull(types.StringType), nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, nonNilStrings(values))
+}
+
+// stringValueOrNull returns a null string for empty LDAP attribute values, so
+// unset optional attributes don't show up as a diff against "".
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
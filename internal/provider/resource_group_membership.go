@@ -17,6 +17,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GroupMembershipResource{}
 var _ resource.ResourceWithImportState = &GroupMembershipResource{}
+var _ resource.ResourceWithModifyPlan = &GroupMembershipResource{}
 
 func NewGroupMembershipResource() resource.Resource {
 	return &GroupMembershipResource{}
@@ -29,9 +30,12 @@ type GroupMembershipResource struct {
 
 // GroupMembershipResourceModel describes the resource data model.
 type GroupMembershipResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	GroupDN  types.String `tfsdk:"group_dn"`
-	MemberDN types.String `tfsdk:"member_dn"`
+	ID         types.String `tfsdk:"id"`
+	GroupDN    types.String `tfsdk:"group_dn"`
+	MemberDN   types.String `tfsdk:"member_dn"`
+	MemberGUID types.String `tfsdk:"member_guid"`
+
+	AllowPrivilegedGroup types.Bool `tfsdk:"allow_privileged_group"`
 }
 
 func (r *GroupMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -58,11 +62,16 @@ func (r *GroupMembershipResource) Schema(ctx context.Context, req resource.Schem
 			},
 			"member_dn": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Distinguished Name of the member (user or group) to add to the group.",
+				MarkdownDescription: "Distinguished Name of the member (user or group) to add to the group. If the member is renamed or moved, this is refreshed to its new DN.",
+			},
+			"member_guid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the member. Used to track the member across renames and moves.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"allow_privileged_group": allowPrivilegedGroupAttribute,
 		},
 	}
 }
@@ -107,6 +116,20 @@ func (r *GroupMembershipResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	// group_dn may have been unknown during planning, so check again now
+	// that it is resolved
+	checkPrivilegedGroup(r.client, groupDN, data.AllowPrivilegedGroup, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolve the member's objectGUID so it can be tracked across renames
+	memberGUID, err := r.client.GetObjectGUID(memberDN)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find member %s, got error: %s", memberDN, err))
+		return
+	}
+
 	// Add member to group
 	err = r.client.AddMemberToGroup(groupDN, memberDN)
 	if err != nil {
@@ -116,6 +139,7 @@ func (r *GroupMembershipResource) Create(ctx context.Context, req resource.Creat
 
 	// Set the ID
 	data.ID = types.StringValue(fmt.Sprintf("%s|%s", groupDN, memberDN))
+	data.MemberGUID = types.StringValue(memberGUID)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -133,9 +157,10 @@ func (r *GroupMembershipResource) Read(ctx context.Context, req resource.ReadReq
 
 	groupDN := data.GroupDN.ValueString()
 	memberDN := data.MemberDN.ValueString()
+	memberGUID := data.MemberGUID.ValueString()
 
-	// Get the group from AD
-	group, err := r.client.GetGroup(groupDN)
+	// Get the group members from AD
+	members, err := r.client.GetGroupMembers(groupDN)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			// Group was deleted outside of Terraform
@@ -146,28 +171,136 @@ func (r *GroupMembershipResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	// Check if the member is still in the group
-	memberFound := false
-	for _, member := range group.Members {
-		if strings.EqualFold(member, memberDN) {
-			memberFound = true
+	// Check if the member is still in the group. Match on objectGUID when it
+	// is known so that renamed or moved members are still recognized; fall
+	// back to the DN for imported or legacy state.
+	var found *client.ExtendedDN
+	for i, member := range members {
+		if memberGUID != "" && strings.EqualFold(member.GUID, memberGUID) {
+			found = &members[i]
+			break
+		}
+		if memberGUID == "" && client.DNEqual(member.DN, memberDN) {
+			found = &members[i]
 			break
 		}
 	}
 
-	if !memberFound {
+	if found == nil {
 		// Member was removed outside of Terraform
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
+	// Refresh the DN in case the member was renamed or moved, keeping the
+	// configured spelling otherwise
+	data.MemberDN = dnValueLike(data.MemberDN, found.DN)
+	data.MemberGUID = types.StringValue(found.GUID)
+	data.ID = types.StringValue(fmt.Sprintf("%s|%s", groupDN, data.MemberDN.ValueString()))
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+func (r *GroupMembershipResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Adding, changing or removing members of privileged groups needs an
+	// explicit opt-in
+	if r.client != nil && !req.Plan.Raw.Equal(req.State.Raw) {
+		var data GroupMembershipResourceModel
+		if req.Plan.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+		} else {
+			resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// An unknown group DN is checked in Create and Update instead
+		if !data.GroupDN.IsUnknown() {
+			checkPrivilegedGroup(r.client, data.GroupDN.ValueString(), data.AllowPrivilegedGroup, &resp.Diagnostics)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	// Nothing else to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state GroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A new member DN may refer to a different object, so the ID and GUID
+	// are only known after apply
+	if !plan.MemberDN.Equal(state.MemberDN) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("member_guid"), types.StringUnknown())...)
+	}
+}
+
 func (r *GroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Group membership doesn't support updates - any change requires replacement
-	// This is handled by the schema with RequiresReplace plan modifiers
+	var data GroupMembershipResourceModel
+	var state GroupMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupDN := data.GroupDN.ValueString()
+	memberDN := data.MemberDN.ValueString()
+
+	// group_dn may have been unknown during planning
+	checkPrivilegedGroup(r.client, groupDN, data.AllowPrivilegedGroup, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The member is tracked by its objectGUID. A configuration that still
+	// names the member by a DN it no longer has, e.g. after a rename outside
+	// of Terraform, refers to the same membership and changes nothing.
+	memberGUID, err := r.client.GetObjectGUID(memberDN)
+	if err != nil {
+		if !client.IsNotFound(err) || state.MemberGUID.ValueString() == "" {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find member %s, got error: %s", memberDN, err))
+			return
+		}
+		memberGUID = state.MemberGUID.ValueString()
+	}
+
+	// If the new DN resolves to the same object, the member was only renamed
+	// or moved and the membership itself is unchanged
+	if !strings.EqualFold(memberGUID, state.MemberGUID.ValueString()) {
+		err = r.client.AddMemberToGroup(groupDN, memberDN)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add member to group, got error: %s", err))
+			return
+		}
+
+		err = r.client.RemoveMemberFromGroup(groupDN, memberReference(state))
+		if err != nil && !isMemberNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove previous member from group, got error: %s", err))
+			return
+		}
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s|%s", groupDN, memberDN))
+	data.MemberGUID = types.StringValue(memberGUID)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *GroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -181,15 +314,12 @@ func (r *GroupMembershipResource) Delete(ctx context.Context, req resource.Delet
 	}
 
 	groupDN := data.GroupDN.ValueString()
-	memberDN := data.MemberDN.ValueString()
 
 	// Remove member from group
-	err := r.client.RemoveMemberFromGroup(groupDN, memberDN)
+	err := r.client.RemoveMemberFromGroup(groupDN, memberReference(data))
 	if err != nil {
 		// Check if it's because the group or member doesn't exist
-		if strings.Contains(err.Error(), "not found") || 
-		   strings.Contains(err.Error(), "does not exist") ||
-		   strings.Contains(err.Error(), "no such object") {
+		if isMemberNotFound(err) {
 			// Already removed, that's fine
 			return
 		}
@@ -216,3 +346,20 @@ func (r *GroupMembershipResource) ImportState(ctx context.Context, req resource.
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_dn"), groupDN)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_dn"), memberDN)...)
 }
+
+// memberReference returns the value used to address the member in the group's
+// member attribute, preferring the GUID form so renames don't break removal
+func memberReference(data GroupMembershipResourceModel) string {
+	if guid := data.MemberGUID.ValueString(); guid != "" {
+		return client.GUIDReference(guid)
+	}
+	return data.MemberDN.ValueString()
+}
+
+// isMemberNotFound reports whether a member removal failed because the group
+// or member no longer exists
+func isMemberNotFound(err error) bool {
+	return strings.Contains(err.Error(), "not found") ||
+		strings.Contains(err.Error(), "does not exist") ||
+		strings.Contains(err.Error(), "no such object")
+}
@@ -0,0 +1,471 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ManagedServiceAccountResource{}
+var _ resource.ResourceWithImportState = &ManagedServiceAccountResource{}
+var _ resource.ResourceWithModifyPlan = &ManagedServiceAccountResource{}
+
+func NewManagedServiceAccountResource() resource.Resource {
+	return &ManagedServiceAccountResource{}
+}
+
+// ManagedServiceAccountResource defines the resource implementation.
+type ManagedServiceAccountResource struct {
+	client *client.Client
+}
+
+// ManagedServiceAccountResourceModel describes the resource data model.
+type ManagedServiceAccountResourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	DN                      types.String `tfsdk:"dn"`
+	Name                    types.String `tfsdk:"name"`
+	OU                      types.String `tfsdk:"ou"`
+	SamAccountName          types.String `tfsdk:"sam_account_name"`
+	DNSHostName             types.String `tfsdk:"dns_host_name"`
+	Description             types.String `tfsdk:"description"`
+	ManagedPasswordInterval types.Int64  `tfsdk:"managed_password_interval"`
+	ServicePrincipalNames   types.Set    `tfsdk:"service_principal_names"`
+	PasswordReaders         types.Set    `tfsdk:"principals_allowed_to_retrieve_managed_password"`
+	ObjectGUID              types.String `tfsdk:"object_guid"`
+	ObjectSid               types.String `tfsdk:"object_sid"`
+}
+
+func (r *ManagedServiceAccountResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_managed_service_account"
+}
+
+func (r *ManagedServiceAccountResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an Active Directory group managed service account (gMSA). The account is tracked by its objectGUID, so renames and moves are applied in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the account.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Distinguished Name of the account.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name (cn) of the account. Changing it renames the account in place.",
+			},
+			"ou": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Container or Organizational Unit for the account (e.g., 'CN=Managed Service Accounts,DC=example,DC=com'). Changing it moves the account in place.",
+			},
+			"sam_account_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "SAM account name of the account, with or without the trailing '$'. Defaults to the name on creation.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dns_host_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "DNS host name of the account (dNSHostName).",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description of the account.",
+			},
+			"managed_password_interval": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(30),
+				MarkdownDescription: "Number of days between password changes (msDS-ManagedPasswordInterval). It can only be set on creation, so changing it replaces the account. Defaults to 30.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"service_principal_names": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Service principal names of the account (servicePrincipalName).",
+			},
+			"principals_allowed_to_retrieve_managed_password": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Distinguished Names of the groups (or computers) allowed to retrieve the managed password, written to msDS-GroupMSAMembership. SIDs are accepted as well; principals that can no longer be resolved are shown by SID.",
+			},
+			"object_guid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the account.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"object_sid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectSid of the account.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ManagedServiceAccountResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ManagedServiceAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planRenamedDN(ctx, req, resp, "name", "ou")
+}
+
+func (r *ManagedServiceAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ManagedServiceAccountResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	samAccountName := data.SamAccountName.ValueString()
+	if data.SamAccountName.IsUnknown() || data.SamAccountName.IsNull() {
+		samAccountName = data.Name.ValueString()
+	}
+
+	attributes, diags := r.ldapAttributes(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dn, err := r.client.CreateManagedServiceAccount(
+		data.OU.ValueString(),
+		data.Name.ValueString(),
+		samAccountName,
+		int(data.ManagedPasswordInterval.ValueInt64()),
+		attributes,
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create managed service account, got error: %s", err))
+		return
+	}
+
+	account, err := r.client.GetManagedServiceAccount(dn)
+	if err != nil {
+		abortCreatedObject("managed service account", dn, err, r.client.DeleteManagedServiceAccount, &resp.Diagnostics)
+		return
+	}
+
+	data.SamAccountName = types.StringValue(samAccountName)
+
+	// Map response body to schema and populate Computed attribute values
+	resp.Diagnostics.Append(r.updateModel(ctx, &data, account)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ManagedServiceAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ManagedServiceAccountResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	account, err := r.client.GetManagedServiceAccountByGUID(data.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			// Account was deleted outside of Terraform
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read managed service account, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateModel(ctx, &data, account)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ManagedServiceAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ManagedServiceAccountResourceModel
+	var state ManagedServiceAccountResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dn := state.DN.ValueString()
+
+	// Rename and/or move in place
+	if !data.Name.Equal(state.Name) || !client.DNEqual(data.OU.ValueString(), state.OU.ValueString()) {
+		newDN, err := r.client.RenameObject(dn, "CN="+client.EscapeDN(data.Name.ValueString()), data.OU.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rename or move managed service account, got error: %s", err))
+			return
+		}
+		dn = newDN
+	}
+
+	planned, diags := r.ldapAttributes(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	current, diags := r.ldapAttributes(ctx, state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Prepare updates
+	updates := make(map[string][]string)
+	for name, values := range planned {
+		if !sameValues(values, current[name]) {
+			updates[name] = values
+		}
+	}
+
+	if !data.SamAccountName.IsUnknown() && !sameComputerSAMAccountName(data.SamAccountName.ValueString(), state.SamAccountName.ValueString()) {
+		updates["sAMAccountName"] = []string{client.ComputerSAMAccountName(data.SamAccountName.ValueString())}
+	}
+
+	if len(updates) > 0 {
+		if err := r.client.UpdateManagedServiceAccount(dn, updates); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update managed service account, got error: %s", err))
+			return
+		}
+	}
+
+	account, err := r.client.GetManagedServiceAccountByGUID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read managed service account after update, got error: %s", err))
+		return
+	}
+
+	if data.SamAccountName.IsUnknown() {
+		data.SamAccountName = state.SamAccountName
+	}
+
+	resp.Diagnostics.Append(r.updateModel(ctx, &data, account)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ManagedServiceAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ManagedServiceAccountResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteManagedServiceAccount(client.GUIDReference(data.ID.ValueString()))
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete managed service account, got error: %s", err))
+		return
+	}
+}
+
+func (r *ManagedServiceAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by objectGUID, DN or SAM account name
+	var account *client.ManagedServiceAccount
+	var err error
+
+	switch {
+	case isGUID(req.ID):
+		account, err = r.client.GetManagedServiceAccountByGUID(req.ID)
+	case strings.Contains(req.ID, "="):
+		account, err = r.client.GetManagedServiceAccount(req.ID)
+	default:
+		account, err = r.client.GetManagedServiceAccountBySAM(req.ID)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Import Failed",
+			fmt.Sprintf("Unable to find managed service account %q by objectGUID, DN or SAM account name: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), account.ObjectGUID)...)
+}
+
+// ldapAttributes returns the LDAP attributes managed through the model.
+// Attributes without a value are empty, so they are cleared on update. The
+// password readers are resolved to their SIDs and encoded as
+// msDS-GroupMSAMembership.
+func (r *ManagedServiceAccountResource) ldapAttributes(ctx context.Context, data ManagedServiceAccountResourceModel) (map[string][]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attributes := map[string][]string{
+		"dNSHostName":             {data.DNSHostName.ValueString()},
+		"description":             {},
+		"servicePrincipalName":    {},
+		"msDS-GroupMSAMembership": {},
+	}
+
+	if !data.Description.IsNull() {
+		attributes["description"] = []string{data.Description.ValueString()}
+	}
+
+	if !data.ServicePrincipalNames.IsNull() {
+		var spns []string
+		diags.Append(data.ServicePrincipalNames.ElementsAs(ctx, &spns, false)...)
+		sort.Strings(spns)
+		attributes["servicePrincipalName"] = nonNilStrings(spns)
+	}
+
+	if !data.PasswordReaders.IsNull() {
+		var readers []string
+		diags.Append(data.PasswordReaders.ElementsAs(ctx, &readers, false)...)
+
+		sids := make([]string, 0, len(readers))
+		for _, reader := range readers {
+			// Unresolvable principals are kept in state by SID
+			sid := reader
+			if !strings.HasPrefix(strings.ToUpper(reader), "S-") {
+				var err error
+				if sid, err = r.client.GetObjectSID(reader); err != nil {
+					diags.AddAttributeError(
+						path.Root("principals_allowed_to_retrieve_managed_password"),
+						"Invalid Principal",
+						fmt.Sprintf("Unable to resolve %q to a security principal: %s", reader, err),
+					)
+					continue
+				}
+			}
+			sids = append(sids, sid)
+		}
+		sort.Strings(sids)
+
+		if len(sids) > 0 {
+			descriptor, err := client.PasswordReadersDescriptor(sids)
+			if err != nil {
+				diags.AddError("Invalid Principal", fmt.Sprintf("Unable to build msDS-GroupMSAMembership: %s", err))
+			} else {
+				attributes["msDS-GroupMSAMembership"] = []string{string(descriptor)}
+			}
+		}
+	}
+
+	return attributes, diags
+}
+
+// updateModel maps a gMSA read from AD onto the resource model.
+func (r *ManagedServiceAccountResource) updateModel(ctx context.Context, data *ManagedServiceAccountResourceModel, account *client.ManagedServiceAccount) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.ID = types.StringValue(account.ObjectGUID)
+	data.DN = types.StringValue(account.DN)
+	data.Name = types.StringValue(account.CN)
+	data.DNSHostName = types.StringValue(account.DNSHostName)
+	data.Description = stringValueOrNull(account.Description)
+	data.ManagedPasswordInterval = types.Int64Value(int64(account.ManagedPasswordInterval))
+	data.ObjectGUID = types.StringValue(account.ObjectGUID)
+	data.ObjectSid = types.StringValue(account.ObjectSid)
+
+	// Keep the configured form of the SAM account name, with or without
+	// the trailing "$"
+	if !sameComputerSAMAccountName(data.SamAccountName.ValueString(), account.SamAccountName) {
+		data.SamAccountName = types.StringValue(strings.TrimSuffix(account.SamAccountName, "$"))
+	}
+
+	data.OU = parentDNLike(data.OU, account.DN)
+
+	spns, setDiags := setValueLike(ctx, data.ServicePrincipalNames, account.ServicePrincipalNames)
+	diags.Append(setDiags...)
+	data.ServicePrincipalNames = spns
+
+	// Map the SIDs back to DNs, keeping the configured spelling
+	var configured []string
+	if !data.PasswordReaders.IsNull() && !data.PasswordReaders.IsUnknown() {
+		diags.Append(data.PasswordReaders.ElementsAs(ctx, &configured, false)...)
+	}
+
+	readers := make([]string, 0, len(account.PasswordReaderSIDs))
+	for _, sid := range account.PasswordReaderSIDs {
+		if containsFold(configured, sid) {
+			readers = append(readers, sid)
+			continue
+		}
+		dn, err := r.client.GetDNBySID(sid)
+		if err != nil {
+			readers = append(readers, sid)
+			continue
+		}
+		for _, value := range configured {
+			if client.DNEqual(value, dn) {
+				dn = value
+				break
+			}
+		}
+		readers = append(readers, dn)
+	}
+
+	set, setDiags := setValueLike(ctx, data.PasswordReaders, readers)
+	diags.Append(setDiags...)
+	data.PasswordReaders = set
+
+	return diags
+}
+
+// containsFold reports whether values contains value, ignoring case.
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,382 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OrganizationalUnitResource{}
+var _ resource.ResourceWithImportState = &OrganizationalUnitResource{}
+var _ resource.ResourceWithModifyPlan = &OrganizationalUnitResource{}
+
+func NewOrganizationalUnitResource() resource.Resource {
+	return &OrganizationalUnitResource{}
+}
+
+// OrganizationalUnitResource defines the resource implementation.
+type OrganizationalUnitResource struct {
+	client *client.Client
+}
+
+// OrganizationalUnitResourceModel describes the resource data model.
+type OrganizationalUnitResourceModel struct {
+	ID                              types.String `tfsdk:"id"`
+	DN                              types.String `tfsdk:"dn"`
+	Name                            types.String `tfsdk:"name"`
+	ParentDN                        types.String `tfsdk:"parent_dn"`
+	Description                     types.String `tfsdk:"description"`
+	ProtectedFromAccidentalDeletion types.Bool   `tfsdk:"protected_from_accidental_deletion"`
+	ForceDestroy                    types.Bool   `tfsdk:"force_destroy"`
+	ObjectGUID                      types.String `tfsdk:"object_guid"`
+}
+
+func (r *OrganizationalUnitResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organizational_unit"
+}
+
+func (r *OrganizationalUnitResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an Active Directory organizational unit. The OU is tracked by its objectGUID, so renames and moves are applied in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the organizational unit.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Distinguished Name of the organizational unit.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the organizational unit. Changing it renames the OU in place.",
+			},
+			"parent_dn": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Distinguished Name of the parent container (e.g., 'DC=example,DC=com'). Changing it moves the OU in place.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description of the organizational unit.",
+			},
+			"protected_from_accidental_deletion": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Deny Everyone the right to delete the OU, as ADUC's \"Protect object from accidental deletion\" does. The OU cannot be destroyed while this is set. Defaults to false.",
+			},
+			"force_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Delete everything below the OU when destroying it, using the tree delete control. Without it, destroying an OU that isn't empty fails. Defaults to false.",
+			},
+			"object_guid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the organizational unit.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *OrganizationalUnitResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OrganizationalUnitResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planRenamedDN(ctx, req, resp, "name", "parent_dn")
+}
+
+func (r *OrganizationalUnitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrganizationalUnitResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attributes := make(map[string][]string)
+	if data.Description.ValueString() != "" {
+		attributes["description"] = []string{data.Description.ValueString()}
+	}
+
+	dn, err := r.client.CreateOrganizationalUnit(data.ParentDN.ValueString(), data.Name.ValueString(), attributes)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create organizational unit, got error: %s", err))
+		return
+	}
+
+	if data.ProtectedFromAccidentalDeletion.ValueBool() {
+		if err := r.client.SetProtectedFromDeletion(dn, true); err != nil {
+			r.abortCreate(ctx, dn, fmt.Errorf("unable to protect organizational unit from accidental deletion: %w", err), resp)
+			return
+		}
+	}
+
+	ou, err := r.client.GetOrganizationalUnit(dn)
+	if err != nil {
+		r.abortCreate(ctx, dn, fmt.Errorf("unable to read organizational unit after creation: %w", err), resp)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	updateOrganizationalUnitResourceModel(&data, ou)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// abortCreate deletes an OU whose creation failed half-way, so the next
+// apply can retry cleanly. If that fails too, the OU is saved to state so
+// Terraform marks it tainted and replaces it.
+func (r *OrganizationalUnitResource) abortCreate(ctx context.Context, dn string, cause error, resp *resource.CreateResponse) {
+	err := r.client.DeleteOrganizationalUnit(dn, false)
+	if err == nil {
+		resp.Diagnostics.AddError(
+			"Organizational Unit Creation Failed",
+			fmt.Sprintf("The organizational unit %s was created but a follow-up step failed, so it was deleted again: %s", dn, cause),
+		)
+		return
+	}
+
+	guid, guidErr := r.client.GetObjectGUID(dn)
+	if guidErr != nil {
+		resp.Diagnostics.AddError(
+			"Organizational Unit Creation Failed",
+			fmt.Sprintf("The organizational unit %s was created but a follow-up step failed: %s\n\n"+
+				"Deleting it also failed (%s). Delete or import it manually.", dn, cause, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), guid)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object_guid"), guid)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dn"), dn)...)
+	resp.Diagnostics.AddError(
+		"Organizational Unit Creation Failed",
+		fmt.Sprintf("The organizational unit %s was created but a follow-up step failed: %s\n\n"+
+			"Deleting the partially created OU also failed (%s), so it has been saved to state and will be replaced on the next apply.", dn, cause, err),
+	)
+}
+
+func (r *OrganizationalUnitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrganizationalUnitResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Look the OU up by GUID so renames and moves outside of Terraform are
+	// picked up
+	ou, err := r.client.GetOrganizationalUnitByGUID(data.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			// OU was deleted outside of Terraform
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organizational unit, got error: %s", err))
+		return
+	}
+
+	protected, err := r.client.IsProtectedFromDeletion(ou.DN)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organizational unit protection, got error: %s", err))
+		return
+	}
+
+	updateOrganizationalUnitResourceModel(&data, ou)
+	data.ProtectedFromAccidentalDeletion = types.BoolValue(protected)
+
+	// Not stored in AD; defaults after import
+	if data.ForceDestroy.IsNull() {
+		data.ForceDestroy = types.BoolValue(false)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationalUnitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data OrganizationalUnitResourceModel
+	var state OrganizationalUnitResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dn := state.DN.ValueString()
+	protected := state.ProtectedFromAccidentalDeletion.ValueBool()
+
+	// Rename and/or move in place
+	renamed := !data.Name.Equal(state.Name)
+	moved := !client.DNEqual(data.ParentDN.ValueString(), state.ParentDN.ValueString())
+	if renamed || moved {
+		// Moving needs the delete rights the protection denies, so lift it
+		// for the duration of the move
+		if moved && protected {
+			if err := r.client.SetProtectedFromDeletion(dn, false); err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to lift accidental deletion protection for the move, got error: %s", err))
+				return
+			}
+			protected = false
+		}
+
+		newDN, err := r.client.RenameOrganizationalUnit(dn, data.Name.ValueString(), data.ParentDN.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rename or move organizational unit, got error: %s", err))
+			return
+		}
+		dn = newDN
+	}
+
+	if data.Description.ValueString() != state.Description.ValueString() {
+		updates := map[string][]string{"description": {}}
+		if data.Description.ValueString() != "" {
+			updates["description"] = []string{data.Description.ValueString()}
+		}
+
+		if err := r.client.UpdateOrganizationalUnit(dn, updates); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update organizational unit, got error: %s", err))
+			return
+		}
+	}
+
+	if data.ProtectedFromAccidentalDeletion.ValueBool() != protected {
+		if err := r.client.SetProtectedFromDeletion(dn, data.ProtectedFromAccidentalDeletion.ValueBool()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change accidental deletion protection, got error: %s", err))
+			return
+		}
+	}
+
+	ou, err := r.client.GetOrganizationalUnitByGUID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organizational unit after update, got error: %s", err))
+		return
+	}
+
+	updateOrganizationalUnitResourceModel(&data, ou)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationalUnitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OrganizationalUnitResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ou, err := r.client.GetOrganizationalUnitByGUID(data.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			// If the OU is already deleted, that's fine
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organizational unit, got error: %s", err))
+		return
+	}
+
+	protected, err := r.client.IsProtectedFromDeletion(ou.DN)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organizational unit protection, got error: %s", err))
+		return
+	}
+
+	if protected {
+		resp.Diagnostics.AddError(
+			"Organizational Unit Is Protected",
+			fmt.Sprintf("The organizational unit %s is protected from accidental deletion. Set 'protected_from_accidental_deletion' to false and apply before destroying it.", ou.DN),
+		)
+		return
+	}
+
+	err = r.client.DeleteOrganizationalUnit(ou.DN, data.ForceDestroy.ValueBool())
+	if client.IsNotAllowedOnNonLeaf(err) {
+		resp.Diagnostics.AddError(
+			"Organizational Unit Not Empty",
+			fmt.Sprintf("The organizational unit %s still contains objects. Remove them first, or set 'force_destroy' to true and apply to delete them along with the OU.", ou.DN),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete organizational unit, got error: %s", err))
+		return
+	}
+}
+
+func (r *OrganizationalUnitResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by objectGUID or DN
+	guid := req.ID
+	if _, err := client.ParseGUID(req.ID); err != nil {
+		ou, err := r.client.GetOrganizationalUnit(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Import Failed",
+				fmt.Sprintf("Expected the objectGUID or Distinguished Name of an organizational unit, got: %q (%s)", req.ID, err),
+			)
+			return
+		}
+		guid = ou.ObjectGUID
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), guid)...)
+}
+
+// updateOrganizationalUnitResourceModel maps an OU read from AD onto the
+// resource model.
+func updateOrganizationalUnitResourceModel(data *OrganizationalUnitResourceModel, ou *client.OrganizationalUnit) {
+	data.ID = types.StringValue(ou.ObjectGUID)
+	data.DN = types.StringValue(ou.DN)
+	data.Name = types.StringValue(ou.Name)
+	data.Description = stringValueLike(data.Description, ou.Description)
+	data.ObjectGUID = types.StringValue(ou.ObjectGUID)
+	data.ParentDN = dnValueLike(data.ParentDN, ou.ParentDN)
+}
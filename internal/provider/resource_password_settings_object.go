@@ -0,0 +1,427 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PasswordSettingsObjectResource{}
+var _ resource.ResourceWithImportState = &PasswordSettingsObjectResource{}
+
+// oneDay converts between the day-based settings and durations
+const oneDay = 24 * time.Hour
+
+func NewPasswordSettingsObjectResource() resource.Resource {
+	return &PasswordSettingsObjectResource{}
+}
+
+// PasswordSettingsObjectResource defines the resource implementation.
+type PasswordSettingsObjectResource struct {
+	client *client.Client
+}
+
+// PasswordSettingsObjectResourceModel describes the resource data model.
+type PasswordSettingsObjectResourceModel struct {
+	ID                              types.String `tfsdk:"id"`
+	DN                              types.String `tfsdk:"dn"`
+	Name                            types.String `tfsdk:"name"`
+	Description                     types.String `tfsdk:"description"`
+	Precedence                      types.Int64  `tfsdk:"precedence"`
+	MinPasswordLength               types.Int64  `tfsdk:"min_password_length"`
+	PasswordHistoryLength           types.Int64  `tfsdk:"password_history_length"`
+	ComplexityEnabled               types.Bool   `tfsdk:"complexity_enabled"`
+	ReversibleEncryptionEnabled     types.Bool   `tfsdk:"reversible_encryption_enabled"`
+	MinPasswordAgeDays              types.Int64  `tfsdk:"min_password_age_days"`
+	MaxPasswordAgeDays              types.Int64  `tfsdk:"max_password_age_days"`
+	LockoutThreshold                types.Int64  `tfsdk:"lockout_threshold"`
+	LockoutObservationWindowMinutes types.Int64  `tfsdk:"lockout_observation_window_minutes"`
+	LockoutDurationMinutes          types.Int64  `tfsdk:"lockout_duration_minutes"`
+	AppliesTo                       types.Set    `tfsdk:"applies_to"`
+	ObjectGUID                      types.String `tfsdk:"object_guid"`
+}
+
+func (r *PasswordSettingsObjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_password_settings_object"
+}
+
+func (r *PasswordSettingsObjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Settings default to the values New-ADFineGrainedPasswordPolicy uses
+	intSetting := func(description string, def, min int64) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(def),
+			MarkdownDescription: fmt.Sprintf("%s Defaults to %d.", description, def),
+			Validators: []validator.Int64{
+				int64AtLeast(min),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a fine-grained password policy: a password settings object (PSO) in the domain's Password Settings Container, applied to groups or users through msDS-PSOAppliesTo.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the PSO.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Distinguished Name of the PSO.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name (cn) of the PSO. Changing it renames the PSO in place.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description of the PSO.",
+			},
+			"precedence": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "Precedence of the PSO (msDS-PasswordSettingsPrecedence). When several PSOs apply to a user, the one with the lowest precedence wins.",
+				Validators: []validator.Int64{
+					int64AtLeast(1),
+				},
+			},
+			"min_password_length":     intSetting("Minimum password length.", 7, 0),
+			"password_history_length": intSetting("Number of previous passwords remembered.", 24, 0),
+			"complexity_enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether passwords must meet complexity requirements. Defaults to true.",
+			},
+			"reversible_encryption_enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether passwords are stored using reversible encryption. Defaults to false.",
+			},
+			"min_password_age_days":              intSetting("Minimum password age in days.", 1, 0),
+			"max_password_age_days":              intSetting("Maximum password age in days; 0 means passwords never expire.", 42, 0),
+			"lockout_threshold":                  intSetting("Number of failed logons before the account is locked out; 0 disables lockout.", 0, 0),
+			"lockout_observation_window_minutes": intSetting("Minutes after which the failed logon counter is reset.", 30, 0),
+			"lockout_duration_minutes":           intSetting("Minutes an account stays locked out; 0 means until an administrator unlocks it.", 30, 0),
+			"applies_to": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Distinguished Names of the groups (or users) the PSO applies to (msDS-PSOAppliesTo).",
+			},
+			"object_guid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the PSO.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PasswordSettingsObjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PasswordSettingsObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PasswordSettingsObjectResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, diags := passwordSettingsFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dn, err := r.client.CreatePasswordSettings(settings)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create password settings object, got error: %s", err))
+		return
+	}
+
+	pso, err := r.client.GetPasswordSettings(dn)
+	if err != nil {
+		// Deleting keeps the next apply from failing with "already exists"
+		if deleteErr := r.client.DeletePasswordSettings(dn); deleteErr != nil {
+			resp.Diagnostics.AddError(
+				"Password Settings Object Creation Failed",
+				fmt.Sprintf("The password settings object %s was created but could not be read back (%s), and deleting it also failed (%s). Delete or import it manually.", dn, err, deleteErr),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Password Settings Object Creation Failed",
+			fmt.Sprintf("The password settings object %s was created but could not be read back, so it was deleted again: %s", dn, err),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	resp.Diagnostics.Append(updatePasswordSettingsObjectResourceModel(ctx, &data, pso)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PasswordSettingsObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PasswordSettingsObjectResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pso, err := r.client.GetPasswordSettingsByGUID(data.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			// PSO was deleted outside of Terraform
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read password settings object, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updatePasswordSettingsObjectResourceModel(ctx, &data, pso)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PasswordSettingsObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PasswordSettingsObjectResourceModel
+	var state PasswordSettingsObjectResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dn := state.DN.ValueString()
+
+	// Rename in place; PSOs always live in the Password Settings Container
+	if !data.Name.Equal(state.Name) {
+		container, err := client.ParentDN(dn)
+		if err == nil {
+			dn, err = r.client.RenameObject(dn, "CN="+client.EscapeDN(data.Name.ValueString()), container)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rename password settings object, got error: %s", err))
+			return
+		}
+	}
+
+	planned, diags := passwordSettingsFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	current, diags := passwordSettingsFromModel(ctx, state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Prepare updates
+	updates := make(map[string][]string)
+	currentAttributes := current.Attributes()
+	for name, values := range planned.Attributes() {
+		if !sameValues(values, currentAttributes[name]) {
+			updates[name] = values
+		}
+	}
+	if planned.Description != current.Description {
+		updates["description"] = nonEmptyValues(planned.Description)
+	}
+	if !sameValues(planned.AppliesTo, current.AppliesTo) {
+		updates["msDS-PSOAppliesTo"] = nonNilStrings(planned.AppliesTo)
+	}
+
+	if len(updates) > 0 {
+		if err := r.client.UpdatePasswordSettings(dn, updates); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update password settings object, got error: %s", err))
+			return
+		}
+	}
+
+	pso, err := r.client.GetPasswordSettingsByGUID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read password settings object after update, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updatePasswordSettingsObjectResourceModel(ctx, &data, pso)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PasswordSettingsObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PasswordSettingsObjectResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeletePasswordSettings(client.GUIDReference(data.ID.ValueString()))
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete password settings object, got error: %s", err))
+		return
+	}
+}
+
+func (r *PasswordSettingsObjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by objectGUID, DN or name
+	var pso *client.PasswordSettings
+	var err error
+
+	switch {
+	case isGUID(req.ID):
+		pso, err = r.client.GetPasswordSettingsByGUID(req.ID)
+	case strings.Contains(req.ID, "="):
+		pso, err = r.client.GetPasswordSettings(req.ID)
+	default:
+		var container string
+		if container, err = r.client.PasswordSettingsContainer(); err == nil {
+			pso, err = r.client.GetPasswordSettings(fmt.Sprintf("CN=%s,%s", client.EscapeDN(req.ID), container))
+		}
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Import Failed",
+			fmt.Sprintf("Unable to find password settings object %q by objectGUID, DN or name: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), pso.ObjectGUID)...)
+}
+
+// passwordSettingsFromModel converts the resource model to the client's
+// password settings.
+func passwordSettingsFromModel(ctx context.Context, data PasswordSettingsObjectResourceModel) (*client.PasswordSettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	settings := &client.PasswordSettings{
+		Name:                        data.Name.ValueString(),
+		Description:                 data.Description.ValueString(),
+		Precedence:                  int(data.Precedence.ValueInt64()),
+		MinPasswordLength:           int(data.MinPasswordLength.ValueInt64()),
+		PasswordHistoryLength:       int(data.PasswordHistoryLength.ValueInt64()),
+		ComplexityEnabled:           data.ComplexityEnabled.ValueBool(),
+		ReversibleEncryptionEnabled: data.ReversibleEncryptionEnabled.ValueBool(),
+		MinPasswordAge:              time.Duration(data.MinPasswordAgeDays.ValueInt64()) * oneDay,
+		MaxPasswordAge:              time.Duration(data.MaxPasswordAgeDays.ValueInt64()) * oneDay,
+		LockoutThreshold:            int(data.LockoutThreshold.ValueInt64()),
+		LockoutObservationWindow:    time.Duration(data.LockoutObservationWindowMinutes.ValueInt64()) * time.Minute,
+		LockoutDuration:             time.Duration(data.LockoutDurationMinutes.ValueInt64()) * time.Minute,
+	}
+
+	if !data.AppliesTo.IsNull() && !data.AppliesTo.IsUnknown() {
+		diags.Append(data.AppliesTo.ElementsAs(ctx, &settings.AppliesTo, false)...)
+	}
+
+	return settings, diags
+}
+
+// updatePasswordSettingsObjectResourceModel maps a PSO read from AD onto
+// the resource model.
+func updatePasswordSettingsObjectResourceModel(ctx context.Context, data *PasswordSettingsObjectResourceModel, pso *client.PasswordSettings) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.ID = types.StringValue(pso.ObjectGUID)
+	data.DN = types.StringValue(pso.DN)
+	data.Name = types.StringValue(pso.Name)
+	data.Description = stringValueOrNull(pso.Description)
+	data.Precedence = types.Int64Value(int64(pso.Precedence))
+	data.MinPasswordLength = types.Int64Value(int64(pso.MinPasswordLength))
+	data.PasswordHistoryLength = types.Int64Value(int64(pso.PasswordHistoryLength))
+	data.ComplexityEnabled = types.BoolValue(pso.ComplexityEnabled)
+	data.ReversibleEncryptionEnabled = types.BoolValue(pso.ReversibleEncryptionEnabled)
+	data.MinPasswordAgeDays = types.Int64Value(int64(pso.MinPasswordAge / oneDay))
+	data.MaxPasswordAgeDays = types.Int64Value(int64(pso.MaxPasswordAge / oneDay))
+	data.LockoutThreshold = types.Int64Value(int64(pso.LockoutThreshold))
+	data.LockoutObservationWindowMinutes = types.Int64Value(int64(pso.LockoutObservationWindow / time.Minute))
+	data.LockoutDurationMinutes = types.Int64Value(int64(pso.LockoutDuration / time.Minute))
+	data.ObjectGUID = types.StringValue(pso.ObjectGUID)
+
+	// Keep the configured spelling of the target DNs
+	var configured []string
+	if !data.AppliesTo.IsNull() && !data.AppliesTo.IsUnknown() {
+		diags.Append(data.AppliesTo.ElementsAs(ctx, &configured, false)...)
+	}
+
+	targets := make([]string, 0, len(pso.AppliesTo))
+	for _, dn := range pso.AppliesTo {
+		for _, value := range configured {
+			if client.DNEqual(value, dn) {
+				dn = value
+				break
+			}
+		}
+		targets = append(targets, dn)
+	}
+
+	appliesTo, setDiags := setValueLike(ctx, data.AppliesTo, targets)
+	diags.Append(setDiags...)
+	data.AppliesTo = appliesTo
+
+	return diags
+}
+
+// nonEmptyValues returns value as a single-valued attribute, or no values
+// if it is empty.
+func nonEmptyValues(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return []string{value}
+}
@@ -0,0 +1,435 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hknerts/terraform-provider-adgroups/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserResource{}
+var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
+
+func NewUserResource() resource.Resource {
+	return &UserResource{}
+}
+
+// UserResource defines the resource implementation.
+type UserResource struct {
+	client *client.Client
+}
+
+// UserResourceModel describes the resource data model.
+type UserResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	DN                   types.String `tfsdk:"dn"`
+	CN                   types.String `tfsdk:"cn"`
+	OU                   types.String `tfsdk:"ou"`
+	SamAccountName       types.String `tfsdk:"sam_account_name"`
+	UserPrincipalName    types.String `tfsdk:"user_principal_name"`
+	GivenName            types.String `tfsdk:"given_name"`
+	Surname              types.String `tfsdk:"surname"`
+	DisplayName          types.String `tfsdk:"display_name"`
+	Email                types.String `tfsdk:"email"`
+	Description          types.String `tfsdk:"description"`
+	Enabled              types.Bool   `tfsdk:"enabled"`
+	PasswordNeverExpires types.Bool   `tfsdk:"password_never_expires"`
+	InitialPassword      types.String `tfsdk:"initial_password"`
+	ObjectGUID           types.String `tfsdk:"object_guid"`
+	ObjectSid            types.String `tfsdk:"object_sid"`
+}
+
+func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an Active Directory user, e.g. a service or test account. The user is tracked by its objectGUID, so renames and moves are applied in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the user.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Distinguished Name of the user.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cn": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Common Name of the user. Changing it renames the user in place.",
+			},
+			"ou": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Organizational Unit containing the user (e.g., 'OU=Service Accounts,DC=example,DC=com'). Changing it moves the user in place.",
+			},
+			"sam_account_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Security Account Manager (SAM) account name.",
+			},
+			"user_principal_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "User Principal Name (UPN), e.g. 'svc-backup@example.com'.",
+			},
+			"given_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Given name (first name) of the user.",
+			},
+			"surname": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Surname (last name) of the user.",
+			},
+			"display_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Display name of the user.",
+			},
+			"email": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Email address of the user.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description of the user.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the account is enabled, through userAccountControl. Creating an enabled account requires 'initial_password'. Defaults to true.",
+			},
+			"password_never_expires": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the password never expires, through userAccountControl. Defaults to false.",
+			},
+			"initial_password": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Password set through unicodePwd when the user is created. It is only used on creation and never read back; changing it later has no effect. Requires 'use_tls' on the provider. The value is stored in plaintext in the Terraform state (this provider's framework version has no write-only attributes); 'sensitive' only hides it from plan output, so protect the state or change the password after creation.",
+			},
+			"object_guid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectGUID of the user.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"object_sid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The objectSid of the user.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planRenamedDN(ctx, req, resp, "cn", "ou")
+
+	// Only creation needs checking beyond that
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan UserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Enabled.ValueBool() && plan.InitialPassword.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("initial_password"),
+			"Missing Initial Password",
+			"Active Directory does not allow enabling an account without a password. Set 'initial_password', or set 'enabled' to false.",
+		)
+	}
+}
+
+func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userAccountControl := client.UACNormalAccount
+	if !data.Enabled.ValueBool() {
+		userAccountControl |= client.UACAccountDisable
+	}
+	if data.PasswordNeverExpires.ValueBool() {
+		userAccountControl |= client.UACDontExpirePassword
+	}
+
+	attributes := make(map[string][]string)
+	for name, value := range userLDAPAttributes(data) {
+		if !value.IsNull() {
+			attributes[name] = []string{value.ValueString()}
+		}
+	}
+
+	dn, err := r.client.CreateUser(
+		data.OU.ValueString(),
+		data.CN.ValueString(),
+		userAccountControl,
+		data.InitialPassword.ValueString(),
+		attributes,
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create user, got error: %s", err))
+		return
+	}
+
+	user, err := r.client.GetUser(dn)
+	if err != nil {
+		abortCreatedObject("user", dn, err, r.client.DeleteUser, &resp.Diagnostics)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	updateUserResourceModel(&data, user)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Look the user up by GUID so renames and moves outside of Terraform
+	// are picked up
+	user, err := r.client.GetUserByGUID(data.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			// User was deleted outside of Terraform
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	updateUserResourceModel(&data, user)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data UserResourceModel
+	var state UserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dn := state.DN.ValueString()
+
+	// Rename and/or move in place
+	if !data.CN.Equal(state.CN) || !client.DNEqual(data.OU.ValueString(), state.OU.ValueString()) {
+		newDN, err := r.client.RenameObject(dn, "CN="+client.EscapeDN(data.CN.ValueString()), data.OU.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rename or move user, got error: %s", err))
+			return
+		}
+		dn = newDN
+	}
+
+	// Prepare updates
+	updates := make(map[string][]string)
+	current := userLDAPAttributes(state)
+	for name, value := range userLDAPAttributes(data) {
+		if value.Equal(current[name]) {
+			continue
+		}
+		if value.IsNull() {
+			updates[name] = []string{}
+		} else {
+			updates[name] = []string{value.ValueString()}
+		}
+	}
+
+	if len(updates) > 0 {
+		if err := r.client.UpdateUser(dn, updates); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user, got error: %s", err))
+			return
+		}
+	}
+
+	// Apply account control changes without touching other flags
+	var set, clear int
+	if !data.Enabled.Equal(state.Enabled) {
+		if data.Enabled.ValueBool() {
+			clear |= client.UACAccountDisable
+		} else {
+			set |= client.UACAccountDisable
+		}
+	}
+	if !data.PasswordNeverExpires.Equal(state.PasswordNeverExpires) {
+		if data.PasswordNeverExpires.ValueBool() {
+			set |= client.UACDontExpirePassword
+		} else {
+			clear |= client.UACDontExpirePassword
+		}
+	}
+	if set != 0 || clear != 0 {
+		if err := r.client.SetUserAccountControl(dn, set, clear); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update userAccountControl, got error: %s", err))
+			return
+		}
+	}
+
+	user, err := r.client.GetUserByGUID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user after update, got error: %s", err))
+		return
+	}
+
+	updateUserResourceModel(&data, user)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteUser(client.GUIDReference(data.ID.ValueString()))
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user, got error: %s", err))
+		return
+	}
+}
+
+func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by objectGUID, UPN, sAMAccountName or DN
+	var user *client.User
+	var err error
+
+	switch {
+	case isGUID(req.ID):
+		user, err = r.client.GetUserByGUID(req.ID)
+	case strings.Contains(req.ID, "="):
+		user, err = r.client.GetUser(req.ID)
+	case strings.Contains(req.ID, "@"):
+		user, err = r.client.GetUserByUPN(req.ID)
+	default:
+		// Accept DOMAIN\user as well
+		samAccountName := req.ID
+		if i := strings.LastIndex(samAccountName, "\\"); i >= 0 {
+			samAccountName = samAccountName[i+1:]
+		}
+		user, err = r.client.GetUserBySAM(samAccountName)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Import Failed",
+			fmt.Sprintf("Unable to find user %q by objectGUID, user principal name, sAMAccountName or DN: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), user.ObjectGUID)...)
+}
+
+// userLDAPAttributes maps the optional string arguments of the user
+// resource to their LDAP attributes.
+func userLDAPAttributes(data UserResourceModel) map[string]types.String {
+	return map[string]types.String{
+		"sAMAccountName":    data.SamAccountName,
+		"userPrincipalName": data.UserPrincipalName,
+		"givenName":         data.GivenName,
+		"sn":                data.Surname,
+		"displayName":       data.DisplayName,
+		"mail":              data.Email,
+		"description":       data.Description,
+	}
+}
+
+// updateUserResourceModel maps a user read from AD onto the resource model.
+func updateUserResourceModel(data *UserResourceModel, user *client.User) {
+	data.ID = types.StringValue(user.ObjectGUID)
+	data.DN = types.StringValue(user.DN)
+	data.CN = types.StringValue(user.CN)
+	data.SamAccountName = types.StringValue(user.SamAccountName)
+	data.UserPrincipalName = stringValueOrNull(user.UserPrincipalName)
+	data.GivenName = stringValueOrNull(user.GivenName)
+	data.Surname = stringValueOrNull(user.Surname)
+	data.DisplayName = stringValueOrNull(user.DisplayName)
+	data.Email = stringValueOrNull(user.Email)
+	data.Description = stringValueOrNull(user.Description)
+	data.Enabled = types.BoolValue(user.Enabled)
+	data.PasswordNeverExpires = types.BoolValue(user.PasswordNeverExpires)
+	data.ObjectGUID = types.StringValue(user.ObjectGUID)
+	data.ObjectSid = types.StringValue(user.ObjectSid)
+	data.OU = parentDNLike(data.OU, user.DN)
+}
+
+// isGUID reports whether value is a GUID string.
+func isGUID(value string) bool {
+	_, err := client.ParseGUID(value)
+	return err == nil
+}